@@ -0,0 +1,283 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const ActivityEndpoint = "activity/get"
+
+// The activity log returns at most 100 events per page.
+const ActivityPageSize = 100
+
+type ActivityObjectType string
+
+const (
+	ActivityItem    ActivityObjectType = "item"
+	ActivityNote    ActivityObjectType = "note"
+	ActivityProject ActivityObjectType = "project"
+	ActivitySection ActivityObjectType = "section"
+)
+
+type ActivityEventType string
+
+const (
+	ActivityAdded       ActivityEventType = "added"
+	ActivityUpdated     ActivityEventType = "updated"
+	ActivityCompleted   ActivityEventType = "completed"
+	ActivityUncompleted ActivityEventType = "uncompleted"
+	ActivityDeleted     ActivityEventType = "deleted"
+	ActivityArchived    ActivityEventType = "archived"
+	ActivityUnarchived  ActivityEventType = "unarchived"
+	ActivityShared      ActivityEventType = "shared"
+	ActivityLeft        ActivityEventType = "left"
+)
+
+type ActivityEvent struct {
+	Id              int                `json:"id"`
+	ObjectType      ActivityObjectType `json:"object_type"`
+	ObjectId        int                `json:"object_id"`
+	EventType       ActivityEventType  `json:"event_type"`
+	EventDate       string             `json:"event_date"`
+	ParentProjectId int                `json:"parent_project_id"`
+	ParentItemId    int                `json:"parent_item_id"`
+	InitiatorId     int                `json:"initiator_id"`
+	ExtraData       ActivityExtraData  `json:"extra_data"`
+}
+
+// ActivityExtraData holds the fields the activity log keeps about the object.
+// Which ones are set depends on the object and event type.
+type ActivityExtraData struct {
+	Content            string `json:"content"`
+	LastContent        string `json:"last_content"`
+	Description        string `json:"description"`
+	LastDescription    string `json:"last_description"`
+	Name               string `json:"name"`
+	LastName           string `json:"last_name"`
+	DueDate            string `json:"due_date"`
+	LastDueDate        string `json:"last_due_date"`
+	ResponsibleUid     int    `json:"responsible_uid"`
+	LastResponsibleUid int    `json:"last_responsible_uid"`
+	ParentName         string `json:"parent_name"`
+	ClientName         string `json:"client"`
+}
+
+func (e *ActivityEvent) Time() (time.Time, error) {
+	return time.Parse(time.RFC3339, e.EventDate)
+}
+
+type activityPage struct {
+	Events []ActivityEvent `json:"events"`
+	Count  int             `json:"count"`
+}
+
+// region GetActivity
+
+type GetActivityParams map[string]string
+
+//goland:noinspection GoUnusedExportedFunction
+func MakeGetActivityParams() *GetActivityParams {
+	params := make(GetActivityParams)
+	return &params
+}
+
+func (p *GetActivityParams) WithObjectType(objectType ActivityObjectType) *GetActivityParams {
+	if objectType != "" {
+		(*p)["object_type"] = string(objectType)
+	}
+
+	return p
+}
+
+func (p *GetActivityParams) WithObjectId(objectId int) *GetActivityParams {
+	if objectId != 0 {
+		(*p)["object_id"] = strconv.Itoa(objectId)
+	}
+
+	return p
+}
+
+func (p *GetActivityParams) WithEventType(eventType ActivityEventType) *GetActivityParams {
+	if eventType != "" {
+		(*p)["event_type"] = string(eventType)
+	}
+
+	return p
+}
+
+// WithObjectEventTypes filters by "object:event" pairs, such as "item:completed".
+// Either part can be left empty to match any object or event.
+func (p *GetActivityParams) WithObjectEventTypes(objectEventTypes []string) *GetActivityParams {
+	if objectEventTypes != nil && len(objectEventTypes) != 0 {
+		value, _ := json.Marshal(objectEventTypes)
+		(*p)["object_event_types"] = string(value)
+	}
+
+	return p
+}
+
+func (p *GetActivityParams) WithParentProjectId(projectId int) *GetActivityParams {
+	if projectId != 0 {
+		(*p)["parent_project_id"] = strconv.Itoa(projectId)
+	}
+
+	return p
+}
+
+func (p *GetActivityParams) WithParentItemId(itemId int) *GetActivityParams {
+	if itemId != 0 {
+		(*p)["parent_item_id"] = strconv.Itoa(itemId)
+	}
+
+	return p
+}
+
+func (p *GetActivityParams) WithInitiatorId(initiatorId int) *GetActivityParams {
+	if initiatorId != 0 {
+		(*p)["initiator_id"] = strconv.Itoa(initiatorId)
+	}
+
+	return p
+}
+
+func (p *GetActivityParams) WithSince(since time.Time) *GetActivityParams {
+	if !since.IsZero() {
+		(*p)["since"] = since.UTC().Format(syncTimeLayout)
+	}
+
+	return p
+}
+
+func (p *GetActivityParams) WithUntil(until time.Time) *GetActivityParams {
+	if !until.IsZero() {
+		(*p)["until"] = until.UTC().Format(syncTimeLayout)
+	}
+
+	return p
+}
+
+// WithLimit caps the total number of returned events. Pages are fetched as needed.
+func (p *GetActivityParams) WithLimit(limit int) *GetActivityParams {
+	if limit != 0 {
+		(*p)["limit"] = strconv.Itoa(limit)
+	}
+
+	return p
+}
+
+// GetActivity returns all matching events, most recent first.
+func (t *Todoist) GetActivity(ctx context.Context, params *GetActivityParams) (events []ActivityEvent, err error) {
+	events = make([]ActivityEvent, 0)
+
+	iterator := t.IterateActivity(ctx, params)
+	for iterator.Next() {
+		events = append(events, *iterator.Event())
+	}
+
+	return events, iterator.Err()
+}
+
+// endregion
+
+// region ActivityIterator
+
+// ActivityIterator reads the activity log page by page:
+//
+//	iterator := client.IterateActivity(ctx, params)
+//	for iterator.Next() {
+//		event := iterator.Event()
+//	}
+//	err := iterator.Err()
+type ActivityIterator struct {
+	todoist *Todoist
+	ctx     context.Context
+	query   map[string]string
+	limit   int
+
+	events []ActivityEvent
+	index  int
+	offset int
+	read   int
+	done   bool
+	err    error
+}
+
+func (t *Todoist) IterateActivity(ctx context.Context, params *GetActivityParams) *ActivityIterator {
+	query := make(map[string]string, len(*params))
+	for key, value := range *params {
+		query[key] = value
+	}
+
+	limit, _ := strconv.Atoi(query["limit"])
+
+	return &ActivityIterator{
+		todoist: t,
+		ctx:     ctx,
+		query:   query,
+		limit:   limit,
+		index:   -1,
+	}
+}
+
+// Next advances to the next event, fetching the next page when needed.
+// It returns false when there are no more events or a request failed.
+func (i *ActivityIterator) Next() bool {
+	if i.err != nil || i.limit != 0 && i.read >= i.limit {
+		return false
+	}
+
+	if i.index+1 >= len(i.events) {
+		if i.done {
+			return false
+		}
+
+		if i.err = i.fetch(); i.err != nil || len(i.events) == 0 {
+			return false
+		}
+	} else {
+		i.index++
+	}
+
+	i.read++
+
+	return true
+}
+
+func (i *ActivityIterator) Event() *ActivityEvent {
+	if i.index < 0 || i.index >= len(i.events) {
+		return nil
+	}
+
+	return &i.events[i.index]
+}
+
+func (i *ActivityIterator) Err() error {
+	return i.err
+}
+
+func (i *ActivityIterator) fetch() (err error) {
+	pageSize := ActivityPageSize
+	if i.limit != 0 && i.limit-i.read < pageSize {
+		pageSize = i.limit - i.read
+	}
+
+	i.query["limit"] = strconv.Itoa(pageSize)
+	i.query["offset"] = strconv.Itoa(i.offset)
+
+	page := new(activityPage)
+	if err = i.todoist.requestUrl(i.ctx, http.MethodGet, SyncBaseUrl, ActivityEndpoint, i.query, nil, page); err != nil {
+		return
+	}
+
+	i.events = page.Events
+	i.index = 0
+	i.offset += len(page.Events)
+	i.done = len(page.Events) < pageSize || page.Count != 0 && i.offset >= page.Count
+
+	return
+}
+
+// endregion
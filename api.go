@@ -1,24 +1,57 @@
 package todoist
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 )
 
 const BaseUrl = "https://api.todoist.com/rest/v1/"
 
+const DefaultRetryAfter = 5 * time.Second
+
+const DefaultMaxRetries = 3
+
+type ResponseError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *ResponseError) Error() string {
+	return e.Status
+}
+
+func IsNotFound(err error) bool {
+	var responseError *ResponseError
+	return errors.As(err, &responseError) && responseError.StatusCode == http.StatusNotFound
+}
+
 type Todoist struct {
 	opts *Opts
 }
 
 type Opts struct {
-	Token   string
-	Client  *http.Client
-	Timeout time.Duration
+	Token       string
+	Client      *http.Client
+	Timeout     time.Duration
+	RateLimiter *RateLimiter
+	// MaxRetries is the number of retries after a 429 response, DefaultMaxRetries
+	// when zero. A negative value disables retries.
+	MaxRetries  int
+	Middlewares []Middleware
+
+	Logger         Logger
+	LogBodies      bool
+	LogBodyLimit   int
+	RedactComments bool
+
+	Tracer Tracer
+	Meter  Meter
 }
 
 //goland:noinspection GoUnusedExportedFunction
@@ -27,6 +60,10 @@ func New(opts *Opts) *Todoist {
 		opts.Timeout = 15 * time.Second
 	}
 
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = DefaultMaxRetries
+	}
+
 	if opts.Client == nil {
 		opts.Client = &http.Client{
 			Timeout: opts.Timeout,
@@ -39,8 +76,88 @@ func New(opts *Opts) *Todoist {
 }
 
 func (t *Todoist) request(ctx context.Context, method string, endpoint string, params map[string]string, payload io.Reader, data interface{}) (err error) {
+	return t.requestUrl(ctx, method, BaseUrl, endpoint, params, payload, data)
+}
+
+func (t *Todoist) requestUrl(ctx context.Context, method string, baseUrl string, endpoint string, params map[string]string, payload io.Reader, data interface{}) (err error) {
+	req := &Request{
+		Operation: operationName(ctx, method, baseUrl, endpoint),
+		Method:    method,
+		BaseUrl:   baseUrl,
+		Endpoint:  endpoint,
+		Params:    params,
+		Header:    make(http.Header),
+		Data:      data,
+	}
+	req.Header.Set(RequestIdHeader, newUuid())
+
+	// The payload is buffered so that middlewares can inspect it and retries can resend it.
+	if payload != nil {
+		if req.Payload, err = io.ReadAll(payload); err != nil {
+			return
+		}
+	}
+
+	handler := t.send
+	for i := len(t.opts.Middlewares) - 1; i >= 0; i-- {
+		handler = t.opts.Middlewares[i](handler)
+	}
+
+	ctx, end := t.startTelemetry(ctx, req)
+	err = handler(ctx, req)
+	end(err)
+
+	return
+}
+
+func (t *Todoist) send(ctx context.Context, req *Request) (err error) {
+	for attempt := 0; ; attempt++ {
+		req.Retries = attempt
+
+		if t.opts.RateLimiter != nil {
+			if err = t.opts.RateLimiter.Wait(ctx); err != nil {
+				return
+			}
+		}
+
+		var retryAfter time.Duration
+		if retryAfter, err = t.do(ctx, req, attempt); err == nil {
+			if t.opts.RateLimiter != nil {
+				t.opts.RateLimiter.Success()
+			}
+
+			return
+		}
+
+		var responseError *ResponseError
+		if !errors.As(err, &responseError) || responseError.StatusCode != http.StatusTooManyRequests {
+			return
+		}
+
+		if t.opts.RateLimiter != nil {
+			t.opts.RateLimiter.Backoff(retryAfter)
+		}
+
+		if attempt >= t.opts.MaxRetries {
+			return
+		}
+
+		if t.opts.RateLimiter == nil {
+			if err = sleep(ctx, retryAfter); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (t *Todoist) do(ctx context.Context, request *Request, attempt int) (retryAfter time.Duration, err error) {
+	var payload io.Reader
+	if request.Payload != nil {
+		payload = bytes.NewReader(request.Payload)
+	}
+
 	var req *http.Request
-	if req, err = http.NewRequestWithContext(ctx, method, BaseUrl+endpoint, payload); err != nil {
+	if req, err = http.NewRequestWithContext(ctx, request.Method, request.BaseUrl+request.Endpoint, payload); err != nil {
 		return
 	}
 
@@ -48,36 +165,79 @@ func (t *Todoist) request(ctx context.Context, method string, endpoint string, p
 	if payload != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	for key, values := range request.Header {
+		req.Header[key] = values
+	}
 
-	if params != nil && len(params) != 0 {
+	if request.Params != nil && len(request.Params) != 0 {
 		query := req.URL.Query()
-		for key, value := range params {
+		for key, value := range request.Params {
 			query.Set(key, value)
 		}
 		req.URL.RawQuery = query.Encode()
 	}
 
+	start := time.Now()
+
 	var res *http.Response
 	if res, err = t.opts.Client.Do(req); err != nil {
+		t.logAttempt(ctx, request, attempt, 0, time.Since(start), nil, err)
 		return
 	}
+	request.StatusCode = res.StatusCode
 	//goland:noinspection GoUnhandledErrorResult
 	defer res.Body.Close()
 
+	var body []byte
+	if body, err = io.ReadAll(res.Body); err != nil {
+		t.logAttempt(ctx, request, attempt, 0, time.Since(start), nil, err)
+		return
+	}
+	t.logAttempt(ctx, request, attempt, res.StatusCode, time.Since(start), body, nil)
+
 	switch res.StatusCode {
 	case http.StatusNoContent:
 		return
 	case http.StatusOK:
 		if res.Header.Get("Content-Type") != "application/json" {
-			return errors.New("invalid response content type")
+			return 0, errors.New("invalid response content type")
 		}
 
-		if err = json.NewDecoder(res.Body).Decode(data); err != nil {
+		if err = json.Unmarshal(body, request.Data); err != nil {
 			return
 		}
 
 		return
+	case http.StatusTooManyRequests:
+		return parseRetryAfter(res.Header.Get("Retry-After")), &ResponseError{StatusCode: res.StatusCode, Status: res.Status}
 	default:
-		return errors.New(res.Status)
+		return 0, &ResponseError{StatusCode: res.StatusCode, Status: res.Status}
+	}
+}
+
+// Retry-After holds either a number of seconds or an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+
+	return DefaultRetryAfter
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
 	}
 }
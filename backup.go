@@ -0,0 +1,399 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const BackupVersion = 1
+
+type BackupDocument struct {
+	Version       int                    `json:"version"`
+	CreatedAt     string                 `json:"created_at"`
+	Projects      []Project              `json:"projects"`
+	Sections      []Section              `json:"sections"`
+	Tasks         []Task                 `json:"tasks"`
+	Labels        []Label                `json:"labels"`
+	Comments      []Comment              `json:"comments"`
+	Collaborators map[int][]Collaborator `json:"collaborators"`
+}
+
+type RestoreOpts struct {
+	DryRun bool
+}
+
+// RestoreResult maps the ids from the backup to the restored ones. Collaborators
+// are invited again, listed by restored project id, and comments whose task or
+// project is missing from the backup are skipped.
+type RestoreResult struct {
+	Projects        map[int]int
+	Sections        map[int]int
+	Tasks           map[int]int
+	Labels          map[int]int
+	Comments        map[int]int
+	Shared          map[int][]string
+	SkippedComments []int
+}
+
+// region Backup
+
+func (t *Todoist) Backup(ctx context.Context, w io.Writer) (err error) {
+	var document *BackupDocument
+	if document, err = t.MakeBackup(ctx); err != nil {
+		return
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(document)
+}
+
+func (t *Todoist) MakeBackup(ctx context.Context) (document *BackupDocument, err error) {
+	document = &BackupDocument{
+		Version:       BackupVersion,
+		CreatedAt:     time.Now().UTC().Format(time.RFC3339),
+		Comments:      make([]Comment, 0),
+		Collaborators: make(map[int][]Collaborator),
+	}
+
+	if document.Projects, err = t.GetProjects(ctx); err != nil {
+		return
+	}
+
+	if document.Sections, err = t.GetSections(ctx, MakeGetSectionsParams()); err != nil {
+		return
+	}
+
+	if document.Tasks, err = t.GetTasks(ctx, MakeGetTasksParams()); err != nil {
+		return
+	}
+
+	if document.Labels, err = t.GetLabels(ctx); err != nil {
+		return
+	}
+
+	var comments []Comment
+	for _, project := range document.Projects {
+		if project.CommentCount != 0 {
+			if comments, err = t.GetComments(ctx, MakeGetCommentsParams().WithProjectId(project.Id)); err != nil {
+				return
+			}
+			document.Comments = append(document.Comments, comments...)
+		}
+
+		if project.Shared {
+			if document.Collaborators[project.Id], err = t.GetCollaborators(ctx, project.Id); err != nil {
+				return
+			}
+		}
+	}
+
+	for _, task := range document.Tasks {
+		if task.CommentCount != 0 {
+			if comments, err = t.GetComments(ctx, MakeGetCommentsParams().WithTaskId(task.Id)); err != nil {
+				return
+			}
+			document.Comments = append(document.Comments, comments...)
+		}
+	}
+
+	return
+}
+
+// endregion
+
+// region Restore
+
+func (t *Todoist) Restore(ctx context.Context, r io.Reader, opts *RestoreOpts) (result *RestoreResult, err error) {
+	document := new(BackupDocument)
+	if err = json.NewDecoder(r).Decode(document); err != nil {
+		return
+	}
+
+	return t.RestoreBackup(ctx, document, opts)
+}
+
+func (t *Todoist) RestoreBackup(ctx context.Context, document *BackupDocument, opts *RestoreOpts) (result *RestoreResult, err error) {
+	if document.Version != BackupVersion {
+		return nil, fmt.Errorf("unsupported backup version %d", document.Version)
+	}
+
+	if opts == nil {
+		opts = new(RestoreOpts)
+	}
+
+	result = &RestoreResult{
+		Projects:        make(map[int]int, len(document.Projects)),
+		Sections:        make(map[int]int, len(document.Sections)),
+		Tasks:           make(map[int]int, len(document.Tasks)),
+		Labels:          make(map[int]int, len(document.Labels)),
+		Comments:        make(map[int]int, len(document.Comments)),
+		Shared:          make(map[int][]string),
+		SkippedComments: make([]int, 0),
+	}
+
+	// Dry runs hand out negative ids so that the remapping can still be inspected.
+	fakeId := 0
+	nextFakeId := func() int {
+		fakeId--
+		return fakeId
+	}
+
+	if err = t.restoreLabels(ctx, document, opts, result, nextFakeId); err != nil {
+		return
+	}
+
+	if err = t.restoreProjects(ctx, document, opts, result, nextFakeId); err != nil {
+		return
+	}
+
+	for _, section := range document.Sections {
+		params := MakeAddSectionParams().
+			WithProjectId(result.Projects[section.ProjectId]).
+			WithName(section.Name).
+			WithOrder(section.Order)
+
+		if opts.DryRun {
+			result.Sections[section.Id] = nextFakeId()
+			continue
+		}
+
+		var added *Section
+		if added, err = t.AddSection(ctx, params); err != nil {
+			return result, fmt.Errorf("restore section %q: %w", section.Name, err)
+		}
+		result.Sections[section.Id] = added.Id
+	}
+
+	if err = t.restoreTasks(ctx, document, opts, result, nextFakeId); err != nil {
+		return
+	}
+
+	for _, comment := range document.Comments {
+		params := MakeAddCommentParams().WithContent(comment.Content)
+		if comment.Attachment != nil {
+			params.WithAttachment(comment.Attachment)
+		}
+
+		if comment.TaskId != 0 {
+			taskId, ok := result.Tasks[comment.TaskId]
+			if !ok {
+				result.SkippedComments = append(result.SkippedComments, comment.Id)
+				continue
+			}
+			params.WithTaskId(taskId)
+		} else {
+			projectId, ok := result.Projects[comment.ProjectId]
+			if !ok {
+				result.SkippedComments = append(result.SkippedComments, comment.Id)
+				continue
+			}
+			params.WithProjectId(projectId)
+		}
+
+		if opts.DryRun {
+			result.Comments[comment.Id] = nextFakeId()
+			continue
+		}
+
+		var added *Comment
+		if added, err = t.AddComment(ctx, params); err != nil {
+			return result, fmt.Errorf("restore comment %d: %w", comment.Id, err)
+		}
+		result.Comments[comment.Id] = added.Id
+	}
+
+	err = t.restoreCollaborators(ctx, document, opts, result)
+
+	return
+}
+
+// Labels are account-wide, so existing ones with the same name are reused.
+func (t *Todoist) restoreLabels(ctx context.Context, document *BackupDocument, opts *RestoreOpts, result *RestoreResult, nextFakeId func() int) (err error) {
+	var labels []Label
+	if labels, err = t.GetLabels(ctx); err != nil {
+		return
+	}
+
+	existing := make(map[string]int, len(labels))
+	for _, label := range labels {
+		existing[label.Name] = label.Id
+	}
+
+	for _, label := range document.Labels {
+		if id, ok := existing[label.Name]; ok {
+			result.Labels[label.Id] = id
+			continue
+		}
+
+		if opts.DryRun {
+			result.Labels[label.Id] = nextFakeId()
+			continue
+		}
+
+		var added *Label
+		params := MakeAddLabelParams().WithName(label.Name).WithColor(label.Color).WithOrder(label.Order).WithFavorite(label.Favorite)
+		if added, err = t.AddLabel(ctx, params); err != nil {
+			return fmt.Errorf("restore label %q: %w", label.Name, err)
+		}
+		result.Labels[label.Id] = added.Id
+	}
+
+	return
+}
+
+// The inbox cannot be created, so the backed up inbox is mapped onto the target one.
+func (t *Todoist) restoreProjects(ctx context.Context, document *BackupDocument, opts *RestoreOpts, result *RestoreResult, nextFakeId func() int) (err error) {
+	var projects []Project
+	if projects, err = t.GetProjects(ctx); err != nil {
+		return
+	}
+
+	inboxId := 0
+	for _, project := range projects {
+		if project.InboxProject {
+			inboxId = project.Id
+			break
+		}
+	}
+
+	MakeProjectTree(document.Projects).Walk(func(node *ProjectNode) bool {
+		if node.InboxProject && inboxId != 0 {
+			result.Projects[node.Id] = inboxId
+			return true
+		}
+
+		params := MakeAddProjectParams().WithName(node.Name).WithColor(node.Color).WithFavorite(node.Favorite)
+		if node.Parent != nil {
+			params.WithParentId(result.Projects[node.Parent.Id])
+		}
+
+		if opts.DryRun {
+			result.Projects[node.Id] = nextFakeId()
+			return true
+		}
+
+		var added *Project
+		if added, err = t.AddProject(ctx, params); err != nil {
+			err = fmt.Errorf("restore project %q: %w", node.Path(), err)
+			return false
+		}
+		result.Projects[node.Id] = added.Id
+
+		return true
+	})
+
+	return
+}
+
+// Collaborators get a new invitation to the restored project, except for the user.
+func (t *Todoist) restoreCollaborators(ctx context.Context, document *BackupDocument, opts *RestoreOpts, result *RestoreResult) (err error) {
+	if len(document.Collaborators) == 0 {
+		return
+	}
+
+	var user *User
+	if user, err = t.GetUser(ctx); err != nil {
+		return
+	}
+
+	commands := make([]SyncCommand, 0)
+	for _, project := range document.Projects {
+		projectId, ok := result.Projects[project.Id]
+		if !ok || project.InboxProject {
+			continue
+		}
+
+		for _, collaborator := range document.Collaborators[project.Id] {
+			if strings.EqualFold(collaborator.Email, user.Email) {
+				continue
+			}
+
+			commands = append(commands, makeShareCommand(projectId, collaborator.Email))
+			result.Shared[projectId] = append(result.Shared[projectId], collaborator.Email)
+		}
+	}
+
+	if len(commands) == 0 || opts.DryRun {
+		return
+	}
+
+	if _, err = t.Sync(WithOperation(ctx, "RestoreCollaborators"), commands...); err != nil {
+		result.Shared = make(map[int][]string)
+		return fmt.Errorf("restore collaborators: %w", err)
+	}
+
+	return
+}
+
+// Subtasks reference their parents, so tasks are restored level by level.
+func (t *Todoist) restoreTasks(ctx context.Context, document *BackupDocument, opts *RestoreOpts, result *RestoreResult, nextFakeId func() int) (err error) {
+	known := make(map[int]bool, len(document.Tasks))
+	for _, task := range document.Tasks {
+		known[task.Id] = true
+	}
+
+	pending := document.Tasks
+	for len(pending) != 0 {
+		postponed := make([]Task, 0)
+		for _, task := range pending {
+			if task.ParentId != 0 && known[task.ParentId] {
+				if _, ok := result.Tasks[task.ParentId]; !ok {
+					postponed = append(postponed, task)
+					continue
+				}
+			}
+
+			params := MakeAddTaskParams().
+				WithContent(task.Content).
+				WithDescription(task.Description).
+				WithProjectId(result.Projects[task.ProjectId]).
+				WithSectionId(result.Sections[task.SectionId]).
+				WithParentId(result.Tasks[task.ParentId]).
+				WithOrder(task.Order).
+				WithPriority(task.Priority)
+
+			labelIds := make([]int, 0, len(task.LabelIds))
+			for _, labelId := range task.LabelIds {
+				if id, ok := result.Labels[labelId]; ok {
+					labelIds = append(labelIds, id)
+				}
+			}
+			params.WithLabelIds(labelIds)
+
+			switch {
+			case task.Due.Recurring:
+				params.WithDueString(task.Due.String)
+			case task.Due.Datetime != "":
+				params.WithDueDatetime(task.Due.Datetime)
+			case task.Due.Date != "":
+				params.WithDueDate(task.Due.Date)
+			}
+
+			if opts.DryRun {
+				result.Tasks[task.Id] = nextFakeId()
+				continue
+			}
+
+			var added *Task
+			if added, err = t.AddTask(ctx, params); err != nil {
+				return fmt.Errorf("restore task %q: %w", task.Content, err)
+			}
+			result.Tasks[task.Id] = added.Id
+		}
+
+		if len(postponed) == len(pending) {
+			return fmt.Errorf("cyclic parent references among %d tasks", len(pending))
+		}
+		pending = postponed
+	}
+
+	return
+}
+
+// endregion
@@ -0,0 +1,161 @@
+package todoist
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func testBackupDocument() *BackupDocument {
+	return &BackupDocument{
+		Version: BackupVersion,
+		Projects: []Project{
+			{Id: 10, Name: "Inbox", InboxProject: true},
+			{Id: 11, Name: "Work", Color: 30, Shared: true},
+			{Id: 12, Name: "Reports", ParentId: 11},
+		},
+		Sections: []Section{
+			{Id: 20, ProjectId: 11, Name: "Later", Order: 2},
+		},
+		Tasks: []Task{
+			{Id: 31, ProjectId: 11, SectionId: 20, ParentId: 30, Content: "Draft", Priority: 1},
+			{Id: 30, ProjectId: 11, SectionId: 20, Content: "Report", Priority: 4, LabelIds: []int{40, 41, 49}, Due: Due{Date: "2024-05-01"}},
+			{Id: 32, ProjectId: 10, Content: "Call", Priority: 1, Due: Due{String: "every monday", Recurring: true, Date: "2024-05-06"}},
+		},
+		Labels: []Label{
+			{Id: 40, Name: "urgent"},
+			{Id: 41, Name: "review", Color: 31},
+		},
+		Comments: []Comment{
+			{Id: 50, TaskId: 31, Content: "First draft"},
+			{Id: 51, ProjectId: 11, Content: "Project note"},
+			{Id: 52, TaskId: 99, Content: "Lost"},
+		},
+		Collaborators: map[int][]Collaborator{
+			11: {{Id: 8, Email: "Me@example.com"}, {Id: 9, Email: "bob@example.com"}},
+		},
+	}
+}
+
+func TestRestoreBackup(t *testing.T) {
+	stub := newAPIStub(t)
+	stub.resources["user"] = map[string]interface{}{"id": 8, "email": "me@example.com"}
+	stub.add(ProjectsEndpoint, map[string]interface{}{"id": 1, "name": "Inbox", "inbox_project": true})
+	stub.add(LabelsEndpoint, map[string]interface{}{"id": 2, "name": "urgent"})
+
+	result, err := stub.client().RestoreBackup(context.Background(), testBackupDocument(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := func(value int) string {
+		return strconv.Itoa(value)
+	}
+
+	tests := []struct {
+		name       string
+		collection string
+		id         int
+		field      string
+		want       string
+	}{
+		{"reused label", "", result.Labels[40], "", "2"},
+		{"inbox", "", result.Projects[10], "", "1"},
+		{"label", LabelsEndpoint, result.Labels[41], "color", "31"},
+		{"project", ProjectsEndpoint, result.Projects[11], "name", "Work"},
+		{"subproject", ProjectsEndpoint, result.Projects[12], "parent_id", id(result.Projects[11])},
+		{"section", SectionsEndpoint, result.Sections[20], "project_id", id(result.Projects[11])},
+		{"task project", TasksEndpoint, result.Tasks[30], "project_id", id(result.Projects[11])},
+		{"task section", TasksEndpoint, result.Tasks[30], "section_id", id(result.Sections[20])},
+		{"task labels", TasksEndpoint, result.Tasks[30], "label_ids", "[" + id(result.Labels[40]) + "," + id(result.Labels[41]) + "]"},
+		{"task due", TasksEndpoint, result.Tasks[30], "due_date", "2024-05-01"},
+		{"subtask", TasksEndpoint, result.Tasks[31], "parent_id", id(result.Tasks[30])},
+		{"inbox task", TasksEndpoint, result.Tasks[32], "project_id", "1"},
+		{"recurring task", TasksEndpoint, result.Tasks[32], "due_string", "every monday"},
+		{"task comment", CommentsEndpoint, result.Comments[50], "task_id", id(result.Tasks[31])},
+		{"project comment", CommentsEndpoint, result.Comments[51], "project_id", id(result.Projects[11])},
+	}
+
+	for _, test := range tests {
+		if test.collection == "" {
+			if got := id(test.id); got != test.want {
+				t.Errorf("%s: got id %s, want %s", test.name, got, test.want)
+			}
+			continue
+		}
+
+		object := stub.object(test.collection, test.id)
+		if object == nil {
+			t.Errorf("%s: %s %d was not created", test.name, test.collection, test.id)
+			continue
+		}
+		if got := stubString(object[test.field]); got != test.want {
+			t.Errorf("%s: got %s %s, want %s", test.name, test.field, got, test.want)
+		}
+	}
+
+	if !reflect.DeepEqual(result.SkippedComments, []int{52}) {
+		t.Errorf("skipped comments: got %v, want [52]", result.SkippedComments)
+	}
+
+	wantShared := map[int][]string{result.Projects[11]: {"bob@example.com"}}
+	if !reflect.DeepEqual(result.Shared, wantShared) {
+		t.Errorf("shared: got %v, want %v", result.Shared, wantShared)
+	}
+
+	commands := stub.syncCommands()
+	if len(commands) != 1 || commands[0].Type != "share_project" {
+		t.Errorf("got commands %+v, want a single share_project", commands)
+	}
+
+	if got := len(stub.objects(ProjectsEndpoint)); got != 3 {
+		t.Errorf("got %d projects, want the inbox and 2 restored ones", got)
+	}
+}
+
+func TestRestoreBackupDryRun(t *testing.T) {
+	stub := newAPIStub(t)
+	stub.resources["user"] = map[string]interface{}{"id": 8, "email": "me@example.com"}
+	stub.add(ProjectsEndpoint, map[string]interface{}{"id": 1, "name": "Inbox", "inbox_project": true})
+
+	result, err := stub.client().RestoreBackup(context.Background(), testBackupDocument(), &RestoreOpts{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, ids := range []map[int]int{result.Labels, result.Sections, result.Tasks, result.Comments} {
+		for from, to := range ids {
+			if to >= 0 {
+				t.Errorf("got id %d for %d, want a negative one", to, from)
+			}
+		}
+	}
+	if result.Projects[10] != 1 || result.Projects[11] >= 0 {
+		t.Errorf("got projects %v, want the inbox mapped and negative ids", result.Projects)
+	}
+	if len(result.Shared) != 1 {
+		t.Errorf("got shared %v, want the planned invitation", result.Shared)
+	}
+
+	for _, request := range stub.requests {
+		if !strings.HasPrefix(request, "GET ") && request != "POST /sync/v8/sync" {
+			t.Errorf("dry run sent %s", request)
+		}
+	}
+	if commands := stub.syncCommands(); len(commands) != 0 {
+		t.Errorf("dry run sent commands %+v", commands)
+	}
+}
+
+func TestRestoreBackupVersion(t *testing.T) {
+	stub := newAPIStub(t)
+
+	if _, err := stub.client().Restore(context.Background(), strings.NewReader(`{"version":2}`), nil); err == nil {
+		t.Error("got no error for an unsupported version")
+	}
+	if len(stub.requests) != 0 {
+		t.Errorf("got requests %v, want none", stub.requests)
+	}
+}
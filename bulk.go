@@ -0,0 +1,192 @@
+package todoist
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+const DefaultBulkWorkers = 4
+const DefaultBulkChunkSize = 50
+
+type BulkOpts struct {
+	Workers   int
+	ChunkSize int
+}
+
+// BulkError maps indexes of the failed input items to their errors.
+type BulkError map[int]error
+
+func (e BulkError) Error() string {
+	indexes := make([]int, 0, len(e))
+	for index := range e {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	return fmt.Sprintf("%d of the bulk requests failed, first at index %d: %s", len(e), indexes[0], e[indexes[0]])
+}
+
+func (o *BulkOpts) workers() int {
+	if o == nil || o.Workers <= 0 {
+		return DefaultBulkWorkers
+	}
+
+	return o.Workers
+}
+
+func (o *BulkOpts) chunkSize() int {
+	if o == nil || o.ChunkSize <= 0 {
+		return DefaultBulkChunkSize
+	}
+
+	return o.ChunkSize
+}
+
+// region RunBulk
+
+// RunBulk calls fn for every index in [0, count) using a bounded number of workers.
+// It doesn't limit the rate itself: requests made by fn go through the client,
+// so they share its timeouts and RateLimiter. Items left over after ctx is done
+// fail with the context error.
+func RunBulk(ctx context.Context, count int, opts *BulkOpts, fn func(ctx context.Context, i int) error) error {
+	jobs := make(chan int)
+	errs := make(BulkError)
+	mutex := sync.Mutex{}
+	wg := sync.WaitGroup{}
+
+	fail := func(i int, err error) {
+		mutex.Lock()
+		errs[i] = err
+		mutex.Unlock()
+	}
+
+	workers := opts.workers()
+	if workers > count {
+		workers = count
+	}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := ctx.Err(); err != nil {
+					fail(i, err)
+				} else if err = fn(ctx, i); err != nil {
+					fail(i, err)
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// runBulk is RunBulk for the client helpers. Clients without a RateLimiter
+// still pace bulk requests with the default limit shared by the token.
+func (t *Todoist) runBulk(ctx context.Context, count int, opts *BulkOpts, fn func(ctx context.Context, i int) error) error {
+	if t.opts.RateLimiter != nil {
+		return RunBulk(ctx, count, opts, fn)
+	}
+
+	limiter := SharedRateLimiter(t.opts.Token, DefaultRateLimit, DefaultRateBurst)
+
+	return RunBulk(ctx, count, opts, func(ctx context.Context, i int) (err error) {
+		if err = limiter.Wait(ctx); err != nil {
+			return
+		}
+
+		return fn(ctx, i)
+	})
+}
+
+// endregion
+
+// region GetTasksByIds
+
+// GetTasksByIds returns the tasks in the order of ids, skipping the ones that
+// do not exist. All ids of a failed chunk are reported in the BulkError.
+func (t *Todoist) GetTasksByIds(ctx context.Context, ids []int, opts *BulkOpts) (tasks []Task, err error) {
+	size := opts.chunkSize()
+	chunks := (len(ids) + size - 1) / size
+	results := make([][]Task, chunks)
+
+	err = t.runBulk(ctx, chunks, opts, func(ctx context.Context, i int) (err error) {
+		end := (i + 1) * size
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		results[i], err = t.GetTasks(ctx, MakeGetTasksParams().WithIds(ids[i*size:end]))
+
+		return
+	})
+
+	if bulkError, ok := err.(BulkError); ok {
+		itemErrors := make(BulkError)
+		for chunk, chunkError := range bulkError {
+			for i := chunk * size; i < (chunk+1)*size && i < len(ids); i++ {
+				itemErrors[i] = chunkError
+			}
+		}
+		err = itemErrors
+	}
+
+	found := make(map[int]Task, len(ids))
+	for _, result := range results {
+		for _, task := range result {
+			found[task.Id] = task
+		}
+	}
+
+	tasks = make([]Task, 0, len(found))
+	for _, id := range ids {
+		if task, ok := found[id]; ok {
+			tasks = append(tasks, task)
+		}
+	}
+
+	return
+}
+
+// endregion
+
+// region GetCommentsForTasks
+
+func (t *Todoist) GetCommentsForTasks(ctx context.Context, taskIds []int, opts *BulkOpts) (comments [][]Comment, err error) {
+	comments = make([][]Comment, len(taskIds))
+	err = t.runBulk(ctx, len(taskIds), opts, func(ctx context.Context, i int) (err error) {
+		comments[i], err = t.GetComments(ctx, MakeGetCommentsParams().WithTaskId(taskIds[i]))
+		return
+	})
+
+	return
+}
+
+// endregion
+
+// region GetSectionsForProjects
+
+func (t *Todoist) GetSectionsForProjects(ctx context.Context, projectIds []int, opts *BulkOpts) (sections [][]Section, err error) {
+	sections = make([][]Section, len(projectIds))
+	err = t.runBulk(ctx, len(projectIds), opts, func(ctx context.Context, i int) (err error) {
+		sections[i], err = t.GetSections(ctx, MakeGetSectionsParams().WithProjectId(projectIds[i]))
+		return
+	})
+
+	return
+}
+
+// endregion
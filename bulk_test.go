@@ -0,0 +1,86 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRunBulk(t *testing.T) {
+	var running, peak int32
+	failure := errors.New("failure")
+
+	err := RunBulk(context.Background(), 20, &BulkOpts{Workers: 3}, func(ctx context.Context, i int) error {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			last := atomic.LoadInt32(&peak)
+			if current <= last || atomic.CompareAndSwapInt32(&peak, last, current) {
+				break
+			}
+		}
+
+		if i%7 == 3 {
+			return failure
+		}
+
+		return nil
+	})
+
+	if peak > 3 {
+		t.Errorf("got %d concurrent calls, want at most 3", peak)
+	}
+
+	var bulkError BulkError
+	if !errors.As(err, &bulkError) {
+		t.Fatalf("got %v, want a BulkError", err)
+	}
+	if len(bulkError) != 3 || bulkError[3] != failure || bulkError[10] != failure || bulkError[17] != failure {
+		t.Errorf("got %v, want failures at 3, 10 and 17", bulkError)
+	}
+}
+
+func TestRunBulkCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := RunBulk(ctx, 5, nil, func(ctx context.Context, i int) error {
+		t.Errorf("called for %d after the context was done", i)
+		return nil
+	})
+
+	var bulkError BulkError
+	if !errors.As(err, &bulkError) || len(bulkError) != 5 || !errors.Is(bulkError[0], context.Canceled) {
+		t.Errorf("got %v, want every item canceled", err)
+	}
+}
+
+func TestGetCommentsForTasks(t *testing.T) {
+	stub := newAPIStub(t)
+	stub.add(CommentsEndpoint, map[string]interface{}{"task_id": 2, "content": "second"})
+	stub.add(CommentsEndpoint, map[string]interface{}{"task_id": 1, "content": "first"})
+	stub.add(CommentsEndpoint, map[string]interface{}{"task_id": 2, "content": "third"})
+
+	comments, err := stub.client().GetCommentsForTasks(context.Background(), []int{1, 2, 3}, &BulkOpts{Workers: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{{"first"}, {"second", "third"}, {}}
+	if len(comments) != len(want) {
+		t.Fatalf("got %d results, want %d", len(comments), len(want))
+	}
+	for i, taskComments := range comments {
+		if len(taskComments) != len(want[i]) {
+			t.Errorf("task %d: got %d comments, want %d", i+1, len(taskComments), len(want[i]))
+			continue
+		}
+		for j, comment := range taskComments {
+			if comment.Content != want[i][j] {
+				t.Errorf("task %d: got %q, want %q", i+1, comment.Content, want[i][j])
+			}
+		}
+	}
+}
@@ -0,0 +1,391 @@
+package todoist
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type CassetteMode int
+
+const (
+	CassetteRecord CassetteMode = iota
+	CassetteReplay
+)
+
+// Headers that change on every call and carry no meaning for the tests.
+var cassetteSkippedHeaders = []string{"Authorization", "Date", RequestIdHeader}
+
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+type CassetteRequest struct {
+	Method string      `json:"method"`
+	Url    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type CassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Status     string      `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Cassette is an http.RoundTripper that records the traffic to a file or
+// replays it from one. Use it as Opts.Client.Transport.
+//
+// Requests are matched on method, path, query and body. JSON bodies are
+// compared by value, ignoring the random command uuids of the Sync API.
+// Identical requests are replayed in the recorded order.
+type Cassette struct {
+	Path         string
+	Mode         CassetteMode
+	Transport    http.RoundTripper
+	Interactions []CassetteInteraction
+
+	mutex sync.Mutex
+	used  []bool
+}
+
+// NewCassetteRecorder records the requests sent through transport, or through
+// http.DefaultTransport if it is nil. Call Save to write the cassette.
+//
+//goland:noinspection GoUnusedExportedFunction
+func NewCassetteRecorder(path string, transport http.RoundTripper) *Cassette {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &Cassette{
+		Path:         path,
+		Mode:         CassetteRecord,
+		Transport:    transport,
+		Interactions: make([]CassetteInteraction, 0),
+	}
+}
+
+// LoadCassette opens a recorded cassette for replay.
+//
+//goland:noinspection GoUnusedExportedFunction
+func LoadCassette(path string) (cassette *Cassette, err error) {
+	var data []byte
+	if data, err = os.ReadFile(path); err != nil {
+		return
+	}
+
+	cassette = &Cassette{
+		Path: path,
+		Mode: CassetteReplay,
+	}
+
+	if err = json.Unmarshal(data, &cassette.Interactions); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	cassette.used = make([]bool, len(cassette.Interactions))
+
+	return
+}
+
+func (c *Cassette) RoundTrip(req *http.Request) (res *http.Response, err error) {
+	var body []byte
+	if req.Body != nil {
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return
+		}
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if c.Mode == CassetteReplay {
+		return c.replay(req, body)
+	}
+
+	return c.record(req, body)
+}
+
+func (c *Cassette) record(req *http.Request, body []byte) (res *http.Response, err error) {
+	if res, err = c.Transport.RoundTrip(req); err != nil {
+		return
+	}
+
+	var resBody []byte
+	resBody, err = io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	scrub := func(value string) string {
+		if token == "" {
+			return value
+		}
+
+		return strings.ReplaceAll(value, token, redacted)
+	}
+
+	interaction := CassetteInteraction{
+		Request: CassetteRequest{
+			Method: req.Method,
+			Url:    scrub(req.URL.String()),
+			Header: cassetteHeader(req.Header, scrub),
+			Body:   scrub(string(body)),
+		},
+		Response: CassetteResponse{
+			StatusCode: res.StatusCode,
+			Status:     res.Status,
+			Header:     cassetteHeader(res.Header, scrub),
+			Body:       scrub(string(resBody)),
+		},
+	}
+
+	c.mutex.Lock()
+	c.Interactions = append(c.Interactions, interaction)
+	c.mutex.Unlock()
+
+	return
+}
+
+func (c *Cassette) replay(req *http.Request, body []byte) (res *http.Response, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Every interaction is replayed once, so that repeated requests get the
+	// responses in the recorded order and extra requests fail.
+	found := -1
+	for i, interaction := range c.Interactions {
+		if !c.used[i] && cassetteMatch(&interaction.Request, req, body) {
+			found = i
+			break
+		}
+	}
+
+	if found == -1 {
+		return nil, fmt.Errorf("cassette %s: no recorded interaction for %s %s %s", c.Path, req.Method, req.URL.RequestURI(), body)
+	}
+	c.used[found] = true
+
+	recorded := c.Interactions[found].Response
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	resBody := cassetteResponseBody(c.Interactions[found].Request.Body, body, recorded.Body)
+	header.Del("Content-Length")
+
+	return &http.Response{
+		Status:        recorded.Status,
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(resBody)),
+		ContentLength: int64(len(resBody)),
+		Request:       req,
+	}, nil
+}
+
+// Save writes the recorded interactions to the cassette file.
+func (c *Cassette) Save() (err error) {
+	if c.Mode != CassetteRecord {
+		return errors.New("cassette is not recording")
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(c.Interactions); err != nil {
+		return
+	}
+
+	return os.WriteFile(c.Path, buffer.Bytes(), 0644)
+}
+
+// Unused returns the recorded interactions that were never replayed.
+func (c *Cassette) Unused() []CassetteInteraction {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	unused := make([]CassetteInteraction, 0)
+	for i, interaction := range c.Interactions {
+		if i >= len(c.used) || !c.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+
+	return unused
+}
+
+func cassetteHeader(header http.Header, scrub func(string) string) http.Header {
+	recorded := make(http.Header, len(header))
+	for key, values := range header {
+		recorded[key] = make([]string, len(values))
+		for i, value := range values {
+			recorded[key][i] = scrub(value)
+		}
+	}
+
+	for _, key := range cassetteSkippedHeaders {
+		recorded.Del(key)
+	}
+
+	return recorded
+}
+
+func cassetteMatch(recorded *CassetteRequest, req *http.Request, body []byte) bool {
+	if recorded.Method != req.Method {
+		return false
+	}
+
+	recordedUrl, err := req.URL.Parse(recorded.Url)
+	if err != nil || recordedUrl.Path != req.URL.Path {
+		return false
+	}
+
+	// Query values are compared regardless of their order.
+	if recordedUrl.Query().Encode() != req.URL.Query().Encode() {
+		return false
+	}
+
+	return cassetteBody(recorded.Body) == cassetteBody(string(body))
+}
+
+// cassetteBody normalizes JSON bodies so that key order and the random uuids
+// and temp ids of Sync commands do not matter. Other bodies are compared as they are.
+func cassetteBody(body string) string {
+	var value interface{}
+	if err := json.Unmarshal([]byte(body), &value); err != nil {
+		return body
+	}
+
+	commands := cassetteCommands(value)
+
+	// Temp ids can be referenced by the arguments of later commands.
+	tempIds := make(map[string]string)
+	for i, command := range commands {
+		delete(command, "uuid")
+		if tempId, ok := command["temp_id"].(string); ok {
+			tempIds[tempId] = "temp_id:" + strconv.Itoa(i)
+		}
+	}
+
+	if len(tempIds) != 0 {
+		value = replaceCassetteStrings(value, tempIds)
+	}
+
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+
+	return string(normalized)
+}
+
+func cassetteCommands(value interface{}) []map[string]interface{} {
+	document, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	items, _ := document["commands"].([]interface{})
+	commands := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		if command, ok := item.(map[string]interface{}); ok {
+			commands = append(commands, command)
+		}
+	}
+
+	return commands
+}
+
+func replaceCassetteStrings(value interface{}, replacements map[string]string) interface{} {
+	switch value := value.(type) {
+	case string:
+		if replacement, ok := replacements[value]; ok {
+			return replacement
+		}
+	case map[string]interface{}:
+		for key, field := range value {
+			value[key] = replaceCassetteStrings(field, replacements)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = replaceCassetteStrings(item, replacements)
+		}
+	}
+
+	return value
+}
+
+// cassetteResponseBody rewrites the sync_status and temp_id_mapping keys of a
+// recorded Sync response to the uuids and temp ids of the replayed request,
+// so that the client finds the statuses and ids of its own commands.
+func cassetteResponseBody(recordedRequest string, request []byte, response string) string {
+	var recordedValue, value interface{}
+	if json.Unmarshal([]byte(recordedRequest), &recordedValue) != nil || json.Unmarshal(request, &value) != nil {
+		return response
+	}
+
+	recordedCommands, commands := cassetteCommands(recordedValue), cassetteCommands(value)
+	if len(recordedCommands) == 0 || len(recordedCommands) != len(commands) {
+		return response
+	}
+
+	ids := make(map[string]string)
+	for i, command := range commands {
+		for _, key := range []string{"uuid", "temp_id"} {
+			if recordedId, ok := recordedCommands[i][key].(string); ok {
+				if id, ok := command[key].(string); ok {
+					ids[recordedId] = id
+				}
+			}
+		}
+	}
+
+	var document map[string]interface{}
+	if err := json.Unmarshal([]byte(response), &document); err != nil {
+		return response
+	}
+
+	for _, key := range []string{"sync_status", "temp_id_mapping"} {
+		mapping, ok := document[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		rewritten := make(map[string]interface{}, len(mapping))
+		for recordedId, field := range mapping {
+			if id, ok := ids[recordedId]; ok {
+				rewritten[id] = field
+			} else {
+				rewritten[recordedId] = field
+			}
+		}
+		document[key] = rewritten
+	}
+
+	rewritten, err := json.Marshal(document)
+	if err != nil {
+		return response
+	}
+
+	return string(rewritten)
+}
@@ -0,0 +1,163 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+// syncStub answers every command with ok and maps every temp id to 42,
+// unless the command args contain "fail", which makes it return an error.
+// Reads return the filter 42.
+func syncStub(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ResourceTypes []string `json:"resource_types"`
+			Commands      []struct {
+				Uuid   string                 `json:"uuid"`
+				TempId string                 `json:"temp_id"`
+				Args   map[string]interface{} `json:"args"`
+			} `json:"commands"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("decode request: %s", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if len(request.ResourceTypes) != 0 {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"filters": []map[string]interface{}{{"id": 42, "name": "P1", "query": "p1"}}})
+			return
+		}
+
+		statuses := make(map[string]interface{})
+		tempIds := make(map[string]int)
+		for _, command := range request.Commands {
+			if command.Args["name"] == "fail" {
+				statuses[command.Uuid] = map[string]interface{}{"error_code": 20, "error": "Invalid name"}
+				continue
+			}
+
+			statuses[command.Uuid] = "ok"
+			if command.TempId != "" {
+				tempIds[command.TempId] = 42
+			}
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"sync_status": statuses, "temp_id_mapping": tempIds})
+	}))
+}
+
+func TestCassetteReplaysSyncCommands(t *testing.T) {
+	server := syncStub(t)
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	ctx := context.Background()
+
+	recorder := NewCassetteRecorder(path, nil)
+	client := New(&Opts{Token: "secret", Client: &http.Client{Transport: rewriteHost(recorder, server.URL)}})
+
+	if _, err := client.AddFilter(ctx, MakeAddFilterParams().WithName("P1").WithQuery("p1")); err != nil {
+		t.Fatalf("record AddFilter: %s", err)
+	}
+	if _, err := client.AddFilter(ctx, MakeAddFilterParams().WithName("fail").WithQuery("p1")); err == nil {
+		t.Fatal("record AddFilter: expected a command error")
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("save: %s", err)
+	}
+	server.Close()
+
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	client = New(&Opts{Token: "secret", Client: &http.Client{Transport: rewriteHost(cassette, server.URL)}})
+
+	filter, err := client.AddFilter(ctx, MakeAddFilterParams().WithName("P1").WithQuery("p1"))
+	if err != nil {
+		t.Fatalf("replay AddFilter: %s", err)
+	}
+	if filter.Id != 42 {
+		t.Errorf("replay AddFilter: got id %d, want 42", filter.Id)
+	}
+
+	_, err = client.AddFilter(ctx, MakeAddFilterParams().WithName("fail").WithQuery("p1"))
+	if syncError, ok := err.(*SyncError); !ok || syncError.ErrorCode != 20 {
+		t.Errorf("replay failing AddFilter: got %v, want the recorded command error", err)
+	}
+
+	if _, err = client.AddFilter(ctx, MakeAddFilterParams().WithName("Other").WithQuery("p1")); err == nil {
+		t.Error("replay unrecorded AddFilter: expected an error")
+	}
+
+	if _, err = client.AddFilter(ctx, MakeAddFilterParams().WithName("P1").WithQuery("p1")); err == nil {
+		t.Error("replay repeated AddFilter: expected an error")
+	}
+}
+
+func TestCassetteMatchesQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `[{"id":1,"content":%q}]`, r.URL.Query().Get("filter"))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	ctx := context.Background()
+
+	recorder := NewCassetteRecorder(path, nil)
+	client := New(&Opts{Token: "secret", Client: &http.Client{Transport: rewriteHost(recorder, server.URL)}})
+
+	if _, err := client.GetTasks(ctx, MakeGetTasksParams().WithFilter("today")); err != nil {
+		t.Fatalf("record GetTasks: %s", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("save: %s", err)
+	}
+
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	client = New(&Opts{Token: "secret", Client: &http.Client{Transport: rewriteHost(cassette, server.URL)}})
+
+	if _, err := client.GetTasks(ctx, MakeGetTasksParams().WithFilter("all")); err == nil {
+		t.Error("replay GetTasks with another filter: expected an error")
+	}
+
+	tasks, err := client.GetTasks(ctx, MakeGetTasksParams().WithFilter("today"))
+	if err != nil {
+		t.Fatalf("replay GetTasks: %s", err)
+	}
+	if len(tasks) != 1 || tasks[0].Content != "today" {
+		t.Errorf("replay GetTasks: got %+v", tasks)
+	}
+}
+
+// rewriteHost sends the requests to the stub server, keeping the path and query.
+func rewriteHost(transport http.RoundTripper, serverUrl string) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		target := fmt.Sprintf("%s%s", serverUrl, req.URL.Path)
+		if req.URL.RawQuery != "" {
+			target += "?" + req.URL.RawQuery
+		}
+		url, err := req.URL.Parse(target)
+		if err != nil {
+			return nil, err
+		}
+		req.URL = url
+		req.Host = url.Host
+
+		return transport.RoundTrip(req)
+	})
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
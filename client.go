@@ -0,0 +1,108 @@
+package todoist
+
+import (
+	"context"
+)
+
+// The service interfaces match the method sets of *Todoist, so code can depend
+// on them and use the todoistmock package in tests.
+
+type TasksService interface {
+	GetTasks(ctx context.Context, params *GetTasksParams) ([]Task, error)
+	AddTask(ctx context.Context, params *AddTaskParams) (*Task, error)
+	GetTask(ctx context.Context, taskId int) (*Task, error)
+	UpdateTask(ctx context.Context, taskId int, params *UpdateTaskParams) error
+	CloseTask(ctx context.Context, taskId int) error
+	ReopenTask(ctx context.Context, taskId int) error
+	MoveTask(ctx context.Context, taskId int, params *MoveTaskParams) error
+	DeleteTask(ctx context.Context, taskId int) error
+	GetCompletedTasks(ctx context.Context, params *GetCompletedTasksParams) ([]CompletedTask, error)
+}
+
+type ProjectsService interface {
+	GetProjects(ctx context.Context) ([]Project, error)
+	AddProject(ctx context.Context, params *AddProjectParams) (*Project, error)
+	GetProject(ctx context.Context, projectId int) (*Project, error)
+	UpdateProject(ctx context.Context, projectId int, params *UpdateProjectParams) error
+	DeleteProject(ctx context.Context, projectId int) error
+	GetCollaborators(ctx context.Context, projectId int) ([]Collaborator, error)
+	ShareProject(ctx context.Context, projectId int, email string) error
+	DeleteCollaborator(ctx context.Context, projectId int, email string) error
+	GetInvitations(ctx context.Context) ([]Invitation, error)
+	AcceptInvitation(ctx context.Context, invitation *Invitation) error
+	RejectInvitation(ctx context.Context, invitation *Invitation) error
+}
+
+type SectionsService interface {
+	GetSections(ctx context.Context, params *GetSectionsParams) ([]Section, error)
+	AddSection(ctx context.Context, params *AddSectionParams) (*Section, error)
+	GetSection(ctx context.Context, sectionId int) (*Section, error)
+	UpdateSection(ctx context.Context, sectionId int, params *UpdateSectionParams) error
+	DeleteSection(ctx context.Context, sectionId int) error
+}
+
+type LabelsService interface {
+	GetLabels(ctx context.Context) ([]Label, error)
+	AddLabel(ctx context.Context, params *AddLabelParams) (*Label, error)
+	GetLabel(ctx context.Context, labelId int) (*Label, error)
+	UpdateLabel(ctx context.Context, labelId int, params *UpdateLabelParams) error
+	DeleteLabel(ctx context.Context, labelId int) error
+}
+
+type CommentsService interface {
+	GetComments(ctx context.Context, params *GetCommentsParams) ([]Comment, error)
+	AddComment(ctx context.Context, params *AddCommentParams) (*Comment, error)
+	GetComment(ctx context.Context, commentId int) (*Comment, error)
+	UpdateComment(ctx context.Context, commentId int, params *UpdateCommentParams) error
+	DeleteComment(ctx context.Context, commentId int) error
+}
+
+type RemindersService interface {
+	GetReminders(ctx context.Context, params *GetRemindersParams) ([]Reminder, error)
+	AddReminder(ctx context.Context, params *AddReminderParams) (*Reminder, error)
+	UpdateReminder(ctx context.Context, reminderId int, params *UpdateReminderParams) error
+	DeleteReminder(ctx context.Context, reminderId int) error
+}
+
+type FiltersService interface {
+	GetFilters(ctx context.Context) ([]Filter, error)
+	AddFilter(ctx context.Context, params *AddFilterParams) (*Filter, error)
+	UpdateFilter(ctx context.Context, filterId int, params *UpdateFilterParams) error
+	DeleteFilter(ctx context.Context, filterId int) error
+	ReorderFilters(ctx context.Context, filterIds []int) error
+	GetFilterTasks(ctx context.Context, filter *Filter) ([]Task, error)
+}
+
+type UserService interface {
+	GetUser(ctx context.Context) (*User, error)
+	UpdateUser(ctx context.Context, params *UpdateUserParams) error
+	UpdateNotificationSettings(ctx context.Context, params *UpdateNotificationSettingsParams) error
+}
+
+type ActivityService interface {
+	GetActivity(ctx context.Context, params *GetActivityParams) ([]ActivityEvent, error)
+}
+
+type StatsService interface {
+	GetProductivityStats(ctx context.Context) (*ProductivityStats, error)
+}
+
+type SyncService interface {
+	Sync(ctx context.Context, commands ...SyncCommand) (*SyncResponse, error)
+}
+
+type Client interface {
+	TasksService
+	ProjectsService
+	SectionsService
+	LabelsService
+	CommentsService
+	RemindersService
+	FiltersService
+	UserService
+	ActivityService
+	StatsService
+	SyncService
+}
+
+var _ Client = (*Todoist)(nil)
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/temoon/todoist-api"
+)
+
+var commentCommands = map[string]command{
+	"list":   listComments,
+	"add":    addComment,
+	"get":    getComment,
+	"update": updateComment,
+	"delete": deleteComments,
+}
+
+var commentColumns = []string{"ID", "TASK", "PROJECT", "POSTED", "CONTENT"}
+
+func commentRow(comment todoist.Comment) []string {
+	return []string{
+		strconv.Itoa(comment.Id),
+		itoa(comment.TaskId),
+		itoa(comment.ProjectId),
+		comment.Posted,
+		comment.Content,
+	}
+}
+
+func listComments(ctx context.Context, app *app, args []string) (err error) {
+	flags := newFlagSet("comments list")
+	projectId := flags.Int("project", 0, "project id")
+	taskId := flags.Int("task", 0, "task id")
+
+	if _, err = parseArgs(flags, args); err != nil {
+		return
+	}
+
+	if (*projectId == 0) == (*taskId == 0) {
+		return errors.New("exactly one of -project and -task is required")
+	}
+
+	params := todoist.MakeGetCommentsParams().
+		WithProjectId(*projectId).
+		WithTaskId(*taskId)
+
+	var comments []todoist.Comment
+	if comments, err = app.client.GetComments(ctx, params); err != nil {
+		return
+	}
+
+	return app.printer.Print(comments, commentColumns, func(i int) []string {
+		return commentRow(comments[i])
+	})
+}
+
+func addComment(ctx context.Context, app *app, args []string) (err error) {
+	flags := newFlagSet("comments add")
+	projectId := flags.Int("project", 0, "project id")
+	taskId := flags.Int("task", 0, "task id")
+	content := flags.String("content", "", `comment content, defaults to the positional arguments, "-" reads stdin`)
+
+	var positional []string
+	if positional, err = parseArgs(flags, args); err != nil {
+		return
+	}
+
+	if (*projectId == 0) == (*taskId == 0) {
+		return errors.New("exactly one of -project and -task is required")
+	}
+
+	if *content, err = readContent(app, *content, positional); err != nil {
+		return
+	}
+
+	params := todoist.MakeAddCommentParams().
+		WithProjectId(*projectId).
+		WithTaskId(*taskId).
+		WithContent(*content)
+
+	var comment *todoist.Comment
+	if comment, err = app.client.AddComment(ctx, params); err != nil {
+		return
+	}
+
+	return app.printer.Print(comment, commentColumns, func(int) []string {
+		return commentRow(*comment)
+	})
+}
+
+func getComment(ctx context.Context, app *app, args []string) (err error) {
+	var commentId int
+	if commentId, err = parseId(args); err != nil {
+		return
+	}
+
+	var comment *todoist.Comment
+	if comment, err = app.client.GetComment(ctx, commentId); err != nil {
+		return
+	}
+
+	return app.printer.Print(comment, commentColumns, func(int) []string {
+		return commentRow(*comment)
+	})
+}
+
+func updateComment(ctx context.Context, app *app, args []string) (err error) {
+	flags := newFlagSet("comments update")
+	content := flags.String("content", "", `comment content, "-" reads stdin`)
+
+	var positional []string
+	if positional, err = parseArgs(flags, args); err != nil {
+		return
+	}
+
+	var commentId int
+	if commentId, err = parseId(positional); err != nil {
+		return
+	}
+
+	if *content, err = readContent(app, *content, nil); err != nil {
+		return
+	}
+
+	if err = app.client.UpdateComment(ctx, commentId, todoist.MakeUpdateCommentParams().WithContent(*content)); err != nil {
+		return
+	}
+
+	return app.printer.Done("updated", commentId)
+}
+
+func deleteComments(ctx context.Context, app *app, args []string) error {
+	return eachId(args, func(commentId int) error {
+		if err := app.client.DeleteComment(ctx, commentId); err != nil {
+			return err
+		}
+
+		return app.printer.Done("deleted", commentId)
+	})
+}
+
+func readContent(app *app, content string, positional []string) (string, error) {
+	if content == "" {
+		content = strings.Join(positional, " ")
+	}
+
+	if content == "-" {
+		data, err := io.ReadAll(app.stdin)
+		if err != nil {
+			return "", err
+		}
+		content = strings.TrimRight(string(data), "\n")
+	}
+
+	if content == "" {
+		return "", errors.New("missing comment content")
+	}
+
+	return content, nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type intsFlag []int
+
+func (f *intsFlag) String() string {
+	values := make([]string, len(*f))
+	for i, value := range *f {
+		values[i] = strconv.Itoa(value)
+	}
+
+	return strings.Join(values, ",")
+}
+
+func (f *intsFlag) Set(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+
+		id, err := strconv.Atoi(item)
+		if err != nil {
+			return err
+		}
+		*f = append(*f, id)
+	}
+
+	return nil
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet(name, flag.ContinueOnError)
+}
+
+func isSet(flags *flag.FlagSet, name string) (set bool) {
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+
+	return
+}
+
+func parseIds(args []string) (ids []int, err error) {
+	if len(args) == 0 {
+		return nil, errors.New("missing id")
+	}
+
+	ids = make([]int, 0, len(args))
+	for _, arg := range args {
+		var id int
+		if id, err = strconv.Atoi(arg); err != nil {
+			return nil, fmt.Errorf("invalid id %q", arg)
+		}
+		ids = append(ids, id)
+	}
+
+	return
+}
+
+func parseId(args []string) (id int, err error) {
+	var ids []int
+	if ids, err = parseIds(args); err != nil {
+		return
+	}
+
+	if len(ids) != 1 {
+		return 0, errors.New("expected a single id")
+	}
+
+	return ids[0], nil
+}
+
+// parseArgs parses flags that may be mixed with positional arguments.
+func parseArgs(flags *flag.FlagSet, args []string) (positional []string, err error) {
+	for {
+		if err = flags.Parse(args); err != nil {
+			return
+		}
+
+		if flags.NArg() == 0 {
+			return
+		}
+
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+}
+
+func itoa(value int) string {
+	if value == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(value)
+}
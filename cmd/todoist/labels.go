@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/temoon/todoist-api"
+)
+
+var labelCommands = map[string]command{
+	"list":   listLabels,
+	"add":    addLabel,
+	"get":    getLabel,
+	"update": updateLabel,
+	"delete": deleteLabels,
+}
+
+var labelColumns = []string{"ID", "ORDER", "COLOR", "FAVORITE", "NAME"}
+
+func labelRow(label todoist.Label) []string {
+	return []string{
+		strconv.Itoa(label.Id),
+		strconv.Itoa(label.Order),
+		itoa(label.Color),
+		strconv.FormatBool(label.Favorite),
+		label.Name,
+	}
+}
+
+func listLabels(ctx context.Context, app *app, _ []string) (err error) {
+	var labels []todoist.Label
+	if labels, err = app.client.GetLabels(ctx); err != nil {
+		return
+	}
+
+	return app.printer.Print(labels, labelColumns, func(i int) []string {
+		return labelRow(labels[i])
+	})
+}
+
+func addLabel(ctx context.Context, app *app, args []string) (err error) {
+	flags := newFlagSet("labels add")
+	name := flags.String("name", "", "label name")
+	order := flags.Int("order", 0, "order among labels")
+	color := flags.Int("color", 0, "color id")
+	favorite := flags.Bool("favorite", false, "mark as favorite")
+
+	if _, err = parseArgs(flags, args); err != nil {
+		return
+	}
+
+	if *name == "" {
+		return errors.New("missing label name")
+	}
+
+	params := todoist.MakeAddLabelParams().
+		WithName(*name).
+		WithOrder(*order).
+		WithColor(*color)
+
+	if isSet(flags, "favorite") {
+		params.WithFavorite(*favorite)
+	}
+
+	var label *todoist.Label
+	if label, err = app.client.AddLabel(ctx, params); err != nil {
+		return
+	}
+
+	return app.printer.Print(label, labelColumns, func(int) []string {
+		return labelRow(*label)
+	})
+}
+
+func getLabel(ctx context.Context, app *app, args []string) (err error) {
+	var labelId int
+	if labelId, err = parseId(args); err != nil {
+		return
+	}
+
+	var label *todoist.Label
+	if label, err = app.client.GetLabel(ctx, labelId); err != nil {
+		return
+	}
+
+	return app.printer.Print(label, labelColumns, func(int) []string {
+		return labelRow(*label)
+	})
+}
+
+func updateLabel(ctx context.Context, app *app, args []string) (err error) {
+	flags := newFlagSet("labels update")
+	name := flags.String("name", "", "label name")
+	order := flags.Int("order", 0, "order among labels")
+	color := flags.Int("color", 0, "color id")
+	favorite := flags.Bool("favorite", false, "mark as favorite")
+
+	var positional []string
+	if positional, err = parseArgs(flags, args); err != nil {
+		return
+	}
+
+	var labelId int
+	if labelId, err = parseId(positional); err != nil {
+		return
+	}
+
+	params := todoist.MakeUpdateLabelParams().
+		WithName(*name).
+		WithOrder(*order).
+		WithColor(*color)
+
+	if isSet(flags, "favorite") {
+		params.WithFavorite(*favorite)
+	}
+
+	if err = app.client.UpdateLabel(ctx, labelId, params); err != nil {
+		return
+	}
+
+	return app.printer.Done("updated", labelId)
+}
+
+func deleteLabels(ctx context.Context, app *app, args []string) error {
+	return eachId(args, func(labelId int) error {
+		if err := app.client.DeleteLabel(ctx, labelId); err != nil {
+			return err
+		}
+
+		return app.printer.Done("deleted", labelId)
+	})
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/temoon/todoist-api"
+)
+
+const usage = `Usage: todoist [flags] <command> <action> [flags] [args]
+
+Commands:
+  tasks        list, add, get, update, close, reopen, delete
+  projects     list, add, get, update, delete, collaborators
+  sections     list, add, get, update, delete
+  labels       list, add, get, update, delete
+  comments     list, add, get, update, delete
+  tui          interactive task browser
+
+The API token is read from -token, the TODOIST_TOKEN environment variable
+or the "token" field of the config file, in that order.
+
+Flags:
+`
+
+type command func(ctx context.Context, app *app, args []string) error
+
+var standalone = map[string]command{
+	"tui": runTUI,
+}
+
+var commands = map[string]map[string]command{
+	"tasks":    taskCommands,
+	"projects": projectCommands,
+	"sections": sectionCommands,
+	"labels":   labelCommands,
+	"comments": commentCommands,
+}
+
+type app struct {
+	client  *todoist.Todoist
+	printer *printer
+	stdin   io.Reader
+}
+
+type config struct {
+	Token  string `json:"token"`
+	Output string `json:"output"`
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			_, _ = fmt.Fprintln(os.Stderr, "todoist:", err)
+		}
+		stop()
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) (err error) {
+	flags := flag.NewFlagSet("todoist", flag.ContinueOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprint(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+
+	token := flags.String("token", "", "API token")
+	configPath := flags.String("config", defaultConfigPath(), "config file")
+	output := flags.String("output", "", "output format: table, json or ndjson")
+	timeout := flags.Duration("timeout", 15*time.Second, "request timeout")
+
+	if err = flags.Parse(args); err != nil {
+		return
+	}
+
+	action, actionArgs := standalone[flags.Arg(0)], flags.Args()
+	if action != nil {
+		actionArgs = actionArgs[1:]
+	} else {
+		if flags.NArg() < 2 {
+			flags.Usage()
+			return flag.ErrHelp
+		}
+
+		actions, ok := commands[flags.Arg(0)]
+		if !ok {
+			return fmt.Errorf("unknown command %q", flags.Arg(0))
+		}
+
+		if action, ok = actions[flags.Arg(1)]; !ok {
+			names := make([]string, 0, len(actions))
+			for name := range actions {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			return fmt.Errorf("unknown %s action %q, expected one of: %s", flags.Arg(0), flags.Arg(1), strings.Join(names, ", "))
+		}
+		actionArgs = actionArgs[2:]
+	}
+
+	var cfg config
+	if cfg, err = loadConfig(*configPath); err != nil {
+		return
+	}
+
+	if *token == "" {
+		*token = os.Getenv("TODOIST_TOKEN")
+	}
+	if *token == "" {
+		*token = cfg.Token
+	}
+	if *token == "" {
+		return errors.New("missing API token")
+	}
+
+	if *output == "" {
+		*output = cfg.Output
+	}
+
+	var p *printer
+	if p, err = newPrinter(stdout, *output); err != nil {
+		return
+	}
+
+	client := todoist.New(&todoist.Opts{
+		Token:   *token,
+		Timeout: *timeout,
+	})
+
+	return action(ctx, &app{client: client, printer: p, stdin: stdin}, actionArgs)
+}
+
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "todoist", "config.json")
+}
+
+func loadConfig(path string) (cfg config, err error) {
+	if path == "" {
+		return
+	}
+
+	var data []byte
+	if data, err = os.ReadFile(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+
+		return
+	}
+
+	if err = json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	outputTable  = "table"
+	outputJSON   = "json"
+	outputNDJSON = "ndjson"
+)
+
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case "":
+		format = outputTable
+	case outputTable, outputJSON, outputNDJSON:
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+
+	return &printer{w: w, format: format}, nil
+}
+
+// Print writes value as JSON, or as a table with the given columns, where row
+// renders a single element. Slices are written one element per line in NDJSON.
+func (p *printer) Print(value interface{}, columns []string, row func(i int) []string) (err error) {
+	switch p.format {
+	case outputJSON:
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(value)
+	case outputNDJSON:
+		encoder := json.NewEncoder(p.w)
+
+		items := reflect.ValueOf(value)
+		if items.Kind() != reflect.Slice {
+			return encoder.Encode(value)
+		}
+
+		for i := 0; i < items.Len(); i++ {
+			if err = encoder.Encode(items.Index(i).Interface()); err != nil {
+				return
+			}
+		}
+
+		return
+	default:
+		count := 1
+		if items := reflect.ValueOf(value); items.Kind() == reflect.Slice {
+			count = items.Len()
+		}
+
+		writer := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+		if _, err = fmt.Fprintln(writer, strings.Join(columns, "\t")); err != nil {
+			return
+		}
+
+		for i := 0; i < count; i++ {
+			cells := row(i)
+			for j, cell := range cells {
+				cells[j] = strings.Replace(cell, "\n", " ", -1)
+			}
+
+			if _, err = fmt.Fprintln(writer, strings.Join(cells, "\t")); err != nil {
+				return
+			}
+		}
+
+		return writer.Flush()
+	}
+}
+
+// Done reports a successful action that has no response body.
+func (p *printer) Done(action string, id int) error {
+	if p.format == outputTable {
+		_, err := fmt.Fprintf(p.w, "%s %d\n", action, id)
+		return err
+	}
+
+	return json.NewEncoder(p.w).Encode(map[string]interface{}{"id": id, "action": action})
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/temoon/todoist-api"
+)
+
+var projectCommands = map[string]command{
+	"list":          listProjects,
+	"add":           addProject,
+	"get":           getProject,
+	"update":        updateProject,
+	"delete":        deleteProjects,
+	"collaborators": listCollaborators,
+}
+
+var projectColumns = []string{"ID", "PARENT", "COLOR", "FAVORITE", "SHARED", "NAME"}
+
+func projectRow(project todoist.Project) []string {
+	return []string{
+		strconv.Itoa(project.Id),
+		itoa(project.ParentId),
+		itoa(project.Color),
+		strconv.FormatBool(project.Favorite),
+		strconv.FormatBool(project.Shared),
+		project.Name,
+	}
+}
+
+func listProjects(ctx context.Context, app *app, _ []string) (err error) {
+	var projects []todoist.Project
+	if projects, err = app.client.GetProjects(ctx); err != nil {
+		return
+	}
+
+	return app.printer.Print(projects, projectColumns, func(i int) []string {
+		return projectRow(projects[i])
+	})
+}
+
+func addProject(ctx context.Context, app *app, args []string) (err error) {
+	flags := newFlagSet("projects add")
+	name := flags.String("name", "", "project name")
+	parentId := flags.Int("parent", 0, "parent project id")
+	color := flags.Int("color", 0, "color id")
+	favorite := flags.Bool("favorite", false, "mark as favorite")
+
+	if _, err = parseArgs(flags, args); err != nil {
+		return
+	}
+
+	if *name == "" {
+		return errors.New("missing project name")
+	}
+
+	params := todoist.MakeAddProjectParams().
+		WithName(*name).
+		WithParentId(*parentId).
+		WithColor(*color)
+
+	if isSet(flags, "favorite") {
+		params.WithFavorite(*favorite)
+	}
+
+	var project *todoist.Project
+	if project, err = app.client.AddProject(ctx, params); err != nil {
+		return
+	}
+
+	return app.printer.Print(project, projectColumns, func(int) []string {
+		return projectRow(*project)
+	})
+}
+
+func getProject(ctx context.Context, app *app, args []string) (err error) {
+	var projectId int
+	if projectId, err = parseId(args); err != nil {
+		return
+	}
+
+	var project *todoist.Project
+	if project, err = app.client.GetProject(ctx, projectId); err != nil {
+		return
+	}
+
+	return app.printer.Print(project, projectColumns, func(int) []string {
+		return projectRow(*project)
+	})
+}
+
+func updateProject(ctx context.Context, app *app, args []string) (err error) {
+	flags := newFlagSet("projects update")
+	name := flags.String("name", "", "project name")
+	color := flags.Int("color", 0, "color id")
+	favorite := flags.Bool("favorite", false, "mark as favorite")
+
+	var positional []string
+	if positional, err = parseArgs(flags, args); err != nil {
+		return
+	}
+
+	var projectId int
+	if projectId, err = parseId(positional); err != nil {
+		return
+	}
+
+	params := todoist.MakeUpdateProjectParams().
+		WithName(*name).
+		WithColor(*color)
+
+	if isSet(flags, "favorite") {
+		params.WithFavorite(*favorite)
+	}
+
+	if err = app.client.UpdateProject(ctx, projectId, params); err != nil {
+		return
+	}
+
+	return app.printer.Done("updated", projectId)
+}
+
+func deleteProjects(ctx context.Context, app *app, args []string) error {
+	return eachId(args, func(projectId int) error {
+		if err := app.client.DeleteProject(ctx, projectId); err != nil {
+			return err
+		}
+
+		return app.printer.Done("deleted", projectId)
+	})
+}
+
+func listCollaborators(ctx context.Context, app *app, args []string) (err error) {
+	var projectId int
+	if projectId, err = parseId(args); err != nil {
+		return
+	}
+
+	var collaborators []todoist.Collaborator
+	if collaborators, err = app.client.GetCollaborators(ctx, projectId); err != nil {
+		return
+	}
+
+	return app.printer.Print(collaborators, []string{"ID", "NAME", "EMAIL"}, func(i int) []string {
+		return []string{strconv.Itoa(collaborators[i].Id), collaborators[i].Name, collaborators[i].Email}
+	})
+}
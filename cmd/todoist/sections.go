@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/temoon/todoist-api"
+)
+
+var sectionCommands = map[string]command{
+	"list":   listSections,
+	"add":    addSection,
+	"get":    getSection,
+	"update": updateSection,
+	"delete": deleteSections,
+}
+
+var sectionColumns = []string{"ID", "PROJECT", "ORDER", "NAME"}
+
+func sectionRow(section todoist.Section) []string {
+	return []string{
+		strconv.Itoa(section.Id),
+		itoa(section.ProjectId),
+		strconv.Itoa(section.Order),
+		section.Name,
+	}
+}
+
+func listSections(ctx context.Context, app *app, args []string) (err error) {
+	flags := newFlagSet("sections list")
+	projectId := flags.Int("project", 0, "project id")
+
+	if _, err = parseArgs(flags, args); err != nil {
+		return
+	}
+
+	var sections []todoist.Section
+	if sections, err = app.client.GetSections(ctx, todoist.MakeGetSectionsParams().WithProjectId(*projectId)); err != nil {
+		return
+	}
+
+	return app.printer.Print(sections, sectionColumns, func(i int) []string {
+		return sectionRow(sections[i])
+	})
+}
+
+func addSection(ctx context.Context, app *app, args []string) (err error) {
+	flags := newFlagSet("sections add")
+	name := flags.String("name", "", "section name")
+	projectId := flags.Int("project", 0, "project id")
+	order := flags.Int("order", 0, "order among sections")
+
+	if _, err = parseArgs(flags, args); err != nil {
+		return
+	}
+
+	if *name == "" || *projectId == 0 {
+		return errors.New("missing section name or project id")
+	}
+
+	params := todoist.MakeAddSectionParams().
+		WithName(*name).
+		WithProjectId(*projectId).
+		WithOrder(*order)
+
+	var section *todoist.Section
+	if section, err = app.client.AddSection(ctx, params); err != nil {
+		return
+	}
+
+	return app.printer.Print(section, sectionColumns, func(int) []string {
+		return sectionRow(*section)
+	})
+}
+
+func getSection(ctx context.Context, app *app, args []string) (err error) {
+	var sectionId int
+	if sectionId, err = parseId(args); err != nil {
+		return
+	}
+
+	var section *todoist.Section
+	if section, err = app.client.GetSection(ctx, sectionId); err != nil {
+		return
+	}
+
+	return app.printer.Print(section, sectionColumns, func(int) []string {
+		return sectionRow(*section)
+	})
+}
+
+func updateSection(ctx context.Context, app *app, args []string) (err error) {
+	flags := newFlagSet("sections update")
+	name := flags.String("name", "", "section name")
+
+	var positional []string
+	if positional, err = parseArgs(flags, args); err != nil {
+		return
+	}
+
+	var sectionId int
+	if sectionId, err = parseId(positional); err != nil {
+		return
+	}
+
+	if err = app.client.UpdateSection(ctx, sectionId, todoist.MakeUpdateSectionParams().WithName(*name)); err != nil {
+		return
+	}
+
+	return app.printer.Done("updated", sectionId)
+}
+
+func deleteSections(ctx context.Context, app *app, args []string) error {
+	return eachId(args, func(sectionId int) error {
+		if err := app.client.DeleteSection(ctx, sectionId); err != nil {
+			return err
+		}
+
+		return app.printer.Done("deleted", sectionId)
+	})
+}
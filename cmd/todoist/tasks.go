@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/temoon/todoist-api"
+)
+
+var taskCommands = map[string]command{
+	"list":   listTasks,
+	"add":    addTask,
+	"get":    getTask,
+	"update": updateTask,
+	"close":  closeTasks,
+	"reopen": reopenTasks,
+	"delete": deleteTasks,
+}
+
+var taskColumns = []string{"ID", "PROJECT", "SECTION", "PARENT", "PRIORITY", "DUE", "CONTENT"}
+
+func taskRow(task todoist.Task) []string {
+	due := task.Due.String
+	if due == "" {
+		due = task.Due.Date
+	}
+
+	priority := task.Priority
+	if priority < 1 || priority > 4 {
+		priority = 1
+	}
+
+	return []string{
+		strconv.Itoa(task.Id),
+		itoa(task.ProjectId),
+		itoa(task.SectionId),
+		itoa(task.ParentId),
+		"p" + strconv.Itoa(5-priority),
+		due,
+		task.Content,
+	}
+}
+
+func listTasks(ctx context.Context, app *app, args []string) (err error) {
+	flags := newFlagSet("tasks list")
+	projectId := flags.Int("project", 0, "project id")
+	sectionId := flags.Int("section", 0, "section id")
+	labelId := flags.Int("label", 0, "label id")
+	filter := flags.String("filter", "", "filter query")
+	lang := flags.String("lang", "", "filter language")
+	var ids intsFlag
+	flags.Var(&ids, "ids", "comma separated task ids")
+
+	if _, err = parseArgs(flags, args); err != nil {
+		return
+	}
+
+	params := todoist.MakeGetTasksParams().
+		WithProjectId(*projectId).
+		WithSectionId(*sectionId).
+		WithLabelId(*labelId).
+		WithFilter(*filter).
+		WithLang(*lang).
+		WithIds(ids)
+
+	var tasks []todoist.Task
+	if tasks, err = app.client.GetTasks(ctx, params); err != nil {
+		return
+	}
+
+	return app.printer.Print(tasks, taskColumns, func(i int) []string {
+		return taskRow(tasks[i])
+	})
+}
+
+func addTask(ctx context.Context, app *app, args []string) (err error) {
+	flags := newFlagSet("tasks add")
+	content := flags.String("content", "", "task content, defaults to the positional arguments")
+	description := flags.String("description", "", "task description")
+	projectId := flags.Int("project", 0, "project id")
+	sectionId := flags.Int("section", 0, "section id")
+	parentId := flags.Int("parent", 0, "parent task id")
+	order := flags.Int("order", 0, "order among siblings")
+	priority := flags.Int("priority", 0, "priority from 1 (normal) to 4 (urgent)")
+	due := flags.String("due", "", "due date in natural language")
+	dueDate := flags.String("due-date", "", "due date as YYYY-MM-DD")
+	dueDatetime := flags.String("due-datetime", "", "due date and time in RFC 3339")
+	dueLang := flags.String("due-lang", "", "language of the due string")
+	assignee := flags.Int("assignee", 0, "responsible user id")
+	var labelIds intsFlag
+	flags.Var(&labelIds, "labels", "comma separated label ids")
+
+	var positional []string
+	if positional, err = parseArgs(flags, args); err != nil {
+		return
+	}
+
+	if *content == "" {
+		*content = strings.Join(positional, " ")
+	}
+	if *content == "" {
+		return errors.New("missing task content")
+	}
+
+	params := todoist.MakeAddTaskParams().
+		WithContent(*content).
+		WithDescription(*description).
+		WithProjectId(*projectId).
+		WithSectionId(*sectionId).
+		WithParentId(*parentId).
+		WithOrder(*order).
+		WithLabelIds(labelIds).
+		WithPriority(*priority).
+		WithDueString(*due).
+		WithDueDate(*dueDate).
+		WithDueDatetime(*dueDatetime).
+		WithDueLang(*dueLang).
+		WithAssignee(*assignee)
+
+	var task *todoist.Task
+	if task, err = app.client.AddTask(ctx, params); err != nil {
+		return
+	}
+
+	return app.printer.Print(task, taskColumns, func(int) []string {
+		return taskRow(*task)
+	})
+}
+
+func getTask(ctx context.Context, app *app, args []string) (err error) {
+	var taskId int
+	if taskId, err = parseId(args); err != nil {
+		return
+	}
+
+	var task *todoist.Task
+	if task, err = app.client.GetTask(ctx, taskId); err != nil {
+		return
+	}
+
+	return app.printer.Print(task, taskColumns, func(int) []string {
+		return taskRow(*task)
+	})
+}
+
+func updateTask(ctx context.Context, app *app, args []string) (err error) {
+	flags := newFlagSet("tasks update")
+	content := flags.String("content", "", "task content")
+	description := flags.String("description", "", "task description")
+	priority := flags.Int("priority", 0, "priority from 1 (normal) to 4 (urgent)")
+	due := flags.String("due", "", "due date in natural language")
+	dueDate := flags.String("due-date", "", "due date as YYYY-MM-DD")
+	dueDatetime := flags.String("due-datetime", "", "due date and time in RFC 3339")
+	dueLang := flags.String("due-lang", "", "language of the due string")
+	assignee := flags.Int("assignee", 0, "responsible user id")
+	var labelIds intsFlag
+	flags.Var(&labelIds, "labels", "comma separated label ids")
+
+	var positional []string
+	if positional, err = parseArgs(flags, args); err != nil {
+		return
+	}
+
+	var taskId int
+	if taskId, err = parseId(positional); err != nil {
+		return
+	}
+
+	params := todoist.MakeUpdateTaskParams().
+		WithContent(*content).
+		WithDescription(*description).
+		WithLabelIds(labelIds).
+		WithPriority(*priority).
+		WithDueString(*due).
+		WithDueDate(*dueDate).
+		WithDueDatetime(*dueDatetime).
+		WithDueLang(*dueLang).
+		WithAssignee(*assignee)
+
+	if err = app.client.UpdateTask(ctx, taskId, params); err != nil {
+		return
+	}
+
+	return app.printer.Done("updated", taskId)
+}
+
+func closeTasks(ctx context.Context, app *app, args []string) error {
+	return eachId(args, func(taskId int) error {
+		if err := app.client.CloseTask(ctx, taskId); err != nil {
+			return err
+		}
+
+		return app.printer.Done("closed", taskId)
+	})
+}
+
+func reopenTasks(ctx context.Context, app *app, args []string) error {
+	return eachId(args, func(taskId int) error {
+		if err := app.client.ReopenTask(ctx, taskId); err != nil {
+			return err
+		}
+
+		return app.printer.Done("reopened", taskId)
+	})
+}
+
+func deleteTasks(ctx context.Context, app *app, args []string) error {
+	return eachId(args, func(taskId int) error {
+		if err := app.client.DeleteTask(ctx, taskId); err != nil {
+			return err
+		}
+
+		return app.printer.Done("deleted", taskId)
+	})
+}
+
+func eachId(args []string, fn func(id int) error) (err error) {
+	var ids []int
+	if ids, err = parseIds(args); err != nil {
+		return
+	}
+
+	for _, id := range ids {
+		if err = fn(id); err != nil {
+			return
+		}
+	}
+
+	return
+}
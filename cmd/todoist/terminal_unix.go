@@ -0,0 +1,74 @@
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// makeRaw switches the terminal to unbuffered input without echo via stty(1),
+// leaving signal handling and output processing untouched.
+func makeRaw(f *os.File) (restore func(), err error) {
+	var state string
+	if state, err = stty(f, "-g"); err != nil {
+		return
+	}
+
+	if _, err = stty(f, "-icanon", "-echo", "min", "1"); err != nil {
+		return
+	}
+
+	return func() {
+		_, _ = stty(f, strings.TrimSpace(state))
+	}, nil
+}
+
+// watchTerminalSize reports window size changes, so that the size is only
+// queried again when it changes.
+func watchTerminalSize() (resized <-chan os.Signal, stop func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGWINCH)
+
+	return signals, func() {
+		signal.Stop(signals)
+	}
+}
+
+func terminalSize(f *os.File) (rows int, cols int) {
+	rows, cols = 24, 80
+
+	size, err := stty(f, "size")
+	if err != nil {
+		return
+	}
+
+	fields := strings.Fields(size)
+	if len(fields) != 2 {
+		return
+	}
+
+	if value, err := strconv.Atoi(fields[0]); err == nil && value > 0 {
+		rows = value
+	}
+
+	if value, err := strconv.Atoi(fields[1]); err == nil && value > 0 {
+		cols = value
+	}
+
+	return
+}
+
+func stty(f *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = f
+
+	out, err := cmd.Output()
+
+	return string(out), err
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+// Windows consoles would stay line buffered and echo every key, so the TUI
+// refuses to start there.
+func makeRaw(*os.File) (restore func(), err error) {
+	return nil, errors.New("the interactive mode is not supported on Windows")
+}
+
+func watchTerminalSize() (resized <-chan os.Signal, stop func()) {
+	return nil, func() {}
+}
+
+func terminalSize(*os.File) (rows int, cols int) {
+	return 24, 80
+}
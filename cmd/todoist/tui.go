@@ -0,0 +1,788 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/temoon/todoist-api"
+)
+
+const (
+	keyUp        = "up"
+	keyDown      = "down"
+	keyLeft      = "left"
+	keyRight     = "right"
+	keyEnter     = "enter"
+	keyEscape    = "esc"
+	keyBackspace = "backspace"
+)
+
+const tuiHelp = "j/k move  c close  1-4 priority  t due  m move  l label  a comment  r refresh  esc back  q quit"
+
+type tuiResult struct {
+	err       error
+	action    string
+	projectId int
+	rollback  func()
+	commit    func()
+}
+
+type tuiRow struct {
+	header string
+	taskId int
+	depth  int
+}
+
+type tui struct {
+	ctx      context.Context
+	client   *todoist.Todoist
+	resolver *todoist.Resolver
+	in       *os.File
+	out      *bufio.Writer
+	keys     chan string
+	results  chan tuiResult
+	resized  <-chan os.Signal
+
+	height int
+	width  int
+
+	tree     *todoist.ProjectTree
+	projects []*todoist.ProjectNode
+	labels   map[int]string
+
+	project  *todoist.ProjectNode
+	sections []todoist.Section
+	tasks    []todoist.Task
+	rows     []tuiRow
+
+	projectCursor int
+	cursor        int
+	pending       int
+	status        string
+}
+
+func runTUI(ctx context.Context, app *app, _ []string) (err error) {
+	if info, err := os.Stdin.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return errors.New("standard input is not a terminal")
+	}
+
+	var restore func()
+	if restore, err = makeRaw(os.Stdin); err != nil {
+		return fmt.Errorf("terminal: %w", err)
+	}
+	defer restore()
+
+	ui := &tui{
+		ctx:      ctx,
+		client:   app.client,
+		resolver: todoist.NewResolver(app.client, 0),
+		in:       os.Stdin,
+		out:      bufio.NewWriter(os.Stdout),
+		keys:     make(chan string),
+		results:  make(chan tuiResult, 64),
+	}
+
+	var stop func()
+	ui.resized, stop = watchTerminalSize()
+	defer stop()
+	ui.height, ui.width = terminalSize(ui.in)
+
+	_, _ = ui.out.WriteString("\x1b[?1049h\x1b[?25l")
+	defer func() {
+		_, _ = ui.out.WriteString("\x1b[?25h\x1b[?1049l")
+		_ = ui.out.Flush()
+	}()
+
+	go ui.readKeys()
+
+	if err = ui.loadProjects(); err != nil {
+		return
+	}
+
+	return ui.loop()
+}
+
+// region Input
+
+func (ui *tui) readKeys() {
+	reader := bufio.NewReader(ui.in)
+	for {
+		r, _, err := reader.ReadRune()
+		if err != nil {
+			close(ui.keys)
+			return
+		}
+
+		key := string(r)
+		switch r {
+		case '\r', '\n':
+			key = keyEnter
+		case 127, '\b':
+			key = keyBackspace
+		case 27:
+			key = keyEscape
+			if reader.Buffered() >= 2 {
+				if next, _ := reader.Peek(2); next[0] == '[' || next[0] == 'O' {
+					_, _ = reader.Discard(2)
+					switch next[1] {
+					case 'A':
+						key = keyUp
+					case 'B':
+						key = keyDown
+					case 'C':
+						key = keyRight
+					case 'D':
+						key = keyLeft
+					}
+				}
+			}
+		}
+
+		select {
+		case ui.keys <- key:
+		case <-ui.ctx.Done():
+			return
+		}
+	}
+}
+
+func (ui *tui) nextKey() (key string, ok bool) {
+	for {
+		select {
+		case <-ui.ctx.Done():
+			return "", false
+		case result := <-ui.results:
+			ui.handleResult(result)
+			ui.render("")
+		case <-ui.resized:
+			ui.height, ui.width = terminalSize(ui.in)
+			ui.render("")
+		case key, ok = <-ui.keys:
+			return
+		}
+	}
+}
+
+func (ui *tui) prompt(label string) (value string, ok bool) {
+	for {
+		ui.render(label + ": " + value + "_")
+
+		var key string
+		if key, ok = ui.nextKey(); !ok {
+			return
+		}
+
+		switch key {
+		case keyEnter:
+			return strings.TrimSpace(value), value != ""
+		case keyEscape:
+			return "", false
+		case keyBackspace:
+			if value != "" {
+				_, size := utf8.DecodeLastRuneInString(value)
+				value = value[:len(value)-size]
+			}
+		case keyUp, keyDown, keyLeft, keyRight:
+		default:
+			value += key
+		}
+	}
+}
+
+// endregion
+
+// region Loop
+
+func (ui *tui) loop() (err error) {
+	for {
+		ui.render("")
+
+		key, ok := ui.nextKey()
+		if !ok {
+			return nil
+		}
+
+		if key == "q" {
+			return nil
+		}
+
+		if ui.project == nil {
+			err = ui.projectsKey(key)
+		} else {
+			err = ui.tasksKey(key)
+		}
+
+		if err != nil {
+			ui.status = err.Error()
+		}
+	}
+}
+
+func (ui *tui) projectsKey(key string) error {
+	switch key {
+	case keyUp, "k":
+		if ui.projectCursor > 0 {
+			ui.projectCursor--
+		}
+	case keyDown, "j":
+		if ui.projectCursor < len(ui.projects)-1 {
+			ui.projectCursor++
+		}
+	case keyEnter, keyRight, "l":
+		if len(ui.projects) != 0 {
+			return ui.openProject(ui.projects[ui.projectCursor])
+		}
+	case "r":
+		return ui.loadProjects()
+	}
+
+	return nil
+}
+
+func (ui *tui) tasksKey(key string) error {
+	task := ui.selectedTask()
+
+	switch key {
+	case keyUp, "k":
+		ui.moveCursor(-1)
+	case keyDown, "j":
+		ui.moveCursor(1)
+	case keyEscape, keyLeft, keyBackspace, "h":
+		ui.project = nil
+		ui.status = ""
+	case "r":
+		return ui.openProject(ui.project)
+	case "c":
+		if task != nil {
+			ui.closeTask(*task)
+		}
+	case "1", "2", "3", "4":
+		if task != nil {
+			priority := 5 - int(key[0]-'0')
+			ui.updateTask(*task, "priority", todoist.MakeUpdateTaskParams().WithPriority(priority), func(task *todoist.Task) {
+				task.Priority = priority
+			})
+		}
+	case "t":
+		if task != nil {
+			if due, ok := ui.prompt("Due"); ok {
+				ui.updateTask(*task, "due", todoist.MakeUpdateTaskParams().WithDueString(due), func(task *todoist.Task) {
+					task.Due = todoist.Due{String: due}
+				})
+			}
+		}
+	case "m":
+		if task != nil {
+			if destination, ok := ui.prompt("Move to (project path and/or /section)"); ok {
+				return ui.moveTask(*task, destination)
+			}
+		}
+	case "l":
+		if task != nil {
+			if name, ok := ui.prompt("Toggle label"); ok {
+				return ui.toggleLabel(*task, name)
+			}
+		}
+	case "a":
+		if task != nil {
+			if content, ok := ui.prompt("Comment"); ok {
+				ui.commentTask(*task, content)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (ui *tui) handleResult(result tuiResult) {
+	ui.pending--
+
+	// The task list of another project was loaded meanwhile, so there is
+	// nothing to roll back or update.
+	stale := ui.project == nil || ui.project.Id != result.projectId
+
+	if result.err != nil {
+		if result.rollback != nil && !stale {
+			result.rollback()
+		}
+		ui.status = result.action + " failed: " + result.err.Error()
+	} else {
+		if result.commit != nil && !stale {
+			result.commit()
+		}
+		ui.status = result.action + " done"
+	}
+
+	ui.buildRows()
+}
+
+func (ui *tui) async(action string, op func() error, rollback func(), commit func()) {
+	ui.pending++
+	ui.buildRows()
+
+	projectId := ui.project.Id
+	go func() {
+		err := op()
+		ui.results <- tuiResult{err: err, action: action, projectId: projectId, rollback: rollback, commit: commit}
+	}()
+}
+
+// endregion
+
+// region Actions
+
+func (ui *tui) closeTask(task todoist.Task) {
+	removed := ui.removeTasks(task.Id)
+
+	ui.async("close", func() error {
+		return ui.client.CloseTask(ui.ctx, task.Id)
+	}, func() {
+		ui.restoreTasks(removed)
+	}, nil)
+}
+
+func (ui *tui) updateTask(task todoist.Task, action string, params *todoist.UpdateTaskParams, apply func(task *todoist.Task)) {
+	previous := task
+	if current := ui.findTask(task.Id); current != nil {
+		apply(current)
+	}
+
+	// The server side copy is fetched as well, since due strings get parsed there.
+	var fresh *todoist.Task
+	ui.async(action, func() (err error) {
+		if err = ui.client.UpdateTask(ui.ctx, task.Id, params); err != nil {
+			return
+		}
+
+		fresh, _ = ui.client.GetTask(ui.ctx, task.Id)
+
+		return nil
+	}, func() {
+		ui.replaceTask(previous)
+	}, func() {
+		if fresh != nil {
+			ui.replaceTask(*fresh)
+		}
+	})
+}
+
+func (ui *tui) moveTask(task todoist.Task, destination string) (err error) {
+	path, sectionName := destination, ""
+	if i := strings.Index(destination, " /"); i != -1 {
+		path, sectionName = strings.TrimSpace(destination[:i]), strings.TrimSpace(destination[i+2:])
+	} else if strings.HasPrefix(destination, "/") {
+		path, sectionName = "", strings.TrimSpace(destination[1:])
+	}
+
+	projectId := ui.project.Id
+	if path != "" {
+		node := ui.tree.Lookup(path)
+		if node == nil {
+			return fmt.Errorf("project %q not found", path)
+		}
+		projectId = node.Id
+	}
+
+	params := todoist.MakeMoveTaskParams()
+	sectionId := 0
+	if sectionName != "" {
+		var section *todoist.Section
+		if section, err = ui.resolver.SectionByName(ui.ctx, projectId, sectionName); err != nil {
+			return
+		}
+		sectionId = section.Id
+		params.WithSectionId(sectionId)
+	} else {
+		params.WithProjectId(projectId)
+	}
+
+	var rollback func()
+	if projectId != ui.project.Id {
+		removed := ui.removeTasks(task.Id)
+		rollback = func() {
+			ui.restoreTasks(removed)
+		}
+	} else {
+		previous := make([]todoist.Task, 0)
+		for _, id := range append([]int{task.Id}, ui.descendants(task.Id)...) {
+			if current := ui.findTask(id); current != nil {
+				previous = append(previous, *current)
+				current.SectionId = sectionId
+			}
+		}
+		if current := ui.findTask(task.Id); current != nil {
+			current.ParentId = 0
+		}
+
+		rollback = func() {
+			for _, task := range previous {
+				ui.replaceTask(task)
+			}
+		}
+	}
+
+	ui.async("move", func() error {
+		return ui.client.MoveTask(ui.ctx, task.Id, params)
+	}, rollback, nil)
+
+	return
+}
+
+func (ui *tui) toggleLabel(task todoist.Task, name string) (err error) {
+	var label *todoist.Label
+	if label, err = ui.resolver.GetOrCreateLabel(ui.ctx, name); err != nil {
+		return
+	}
+	ui.labels[label.Id] = label.Name
+
+	labelIds := make([]int, 0, len(task.LabelIds)+1)
+	found := false
+	for _, labelId := range task.LabelIds {
+		if labelId == label.Id {
+			found = true
+		} else {
+			labelIds = append(labelIds, labelId)
+		}
+	}
+	if !found {
+		labelIds = append(labelIds, label.Id)
+	}
+
+	// The builder skips empty lists, but removing the last label needs one.
+	params := todoist.MakeUpdateTaskParams()
+	(*params)["label_ids"] = labelIds
+
+	ui.updateTask(task, "label", params, func(task *todoist.Task) {
+		task.LabelIds = labelIds
+	})
+
+	return
+}
+
+func (ui *tui) commentTask(task todoist.Task, content string) {
+	if current := ui.findTask(task.Id); current != nil {
+		current.CommentCount++
+	}
+
+	ui.async("comment", func() error {
+		_, err := ui.client.AddComment(ui.ctx, todoist.MakeAddCommentParams().WithTaskId(task.Id).WithContent(content))
+		return err
+	}, func() {
+		if current := ui.findTask(task.Id); current != nil {
+			current.CommentCount--
+		}
+	}, nil)
+}
+
+// endregion
+
+// region State
+
+func (ui *tui) loadProjects() (err error) {
+	ui.render("Loading projects...")
+
+	if ui.tree, err = ui.client.GetProjectTree(ui.ctx); err != nil {
+		return
+	}
+
+	var labels []todoist.Label
+	if labels, err = ui.client.GetLabels(ui.ctx); err != nil {
+		return
+	}
+
+	ui.labels = make(map[int]string, len(labels))
+	for _, label := range labels {
+		ui.labels[label.Id] = label.Name
+	}
+
+	ui.projects = ui.projects[:0]
+	ui.tree.Walk(func(node *todoist.ProjectNode) bool {
+		ui.projects = append(ui.projects, node)
+		return true
+	})
+
+	if ui.projectCursor >= len(ui.projects) {
+		ui.projectCursor = 0
+	}
+
+	return
+}
+
+func (ui *tui) openProject(project *todoist.ProjectNode) (err error) {
+	ui.render("Loading " + project.Path() + "...")
+
+	var sections []todoist.Section
+	if sections, err = ui.client.GetSections(ui.ctx, todoist.MakeGetSectionsParams().WithProjectId(project.Id)); err != nil {
+		return
+	}
+
+	var tasks []todoist.Task
+	if tasks, err = ui.client.GetTasks(ui.ctx, todoist.MakeGetTasksParams().WithProjectId(project.Id)); err != nil {
+		return
+	}
+
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sections[i].Order < sections[j].Order
+	})
+
+	ui.project, ui.sections, ui.tasks = project, sections, tasks
+	ui.cursor = 0
+	ui.status = ""
+	ui.buildRows()
+	ui.moveCursor(0)
+
+	return
+}
+
+func (ui *tui) buildRows() {
+	if ui.project == nil {
+		return
+	}
+
+	selected := 0
+	if task := ui.selectedTask(); task != nil {
+		selected = task.Id
+	}
+
+	sort.SliceStable(ui.tasks, func(i, j int) bool {
+		return ui.tasks[i].Order < ui.tasks[j].Order
+	})
+
+	known := make(map[int]bool, len(ui.tasks))
+	children := make(map[int][]int)
+	for _, task := range ui.tasks {
+		known[task.Id] = true
+		children[task.ParentId] = append(children[task.ParentId], task.Id)
+	}
+
+	ui.rows = ui.rows[:0]
+
+	var addTask func(taskId int, depth int)
+	addTask = func(taskId int, depth int) {
+		ui.rows = append(ui.rows, tuiRow{taskId: taskId, depth: depth})
+		for _, childId := range children[taskId] {
+			addTask(childId, depth+1)
+		}
+	}
+
+	addSection := func(sectionId int) {
+		for _, task := range ui.tasks {
+			if task.SectionId == sectionId && (task.ParentId == 0 || !known[task.ParentId]) {
+				addTask(task.Id, 0)
+			}
+		}
+	}
+
+	addSection(0)
+	for _, section := range ui.sections {
+		ui.rows = append(ui.rows, tuiRow{header: section.Name})
+		addSection(section.Id)
+	}
+
+	for i, row := range ui.rows {
+		if row.taskId == selected && selected != 0 {
+			ui.cursor = i
+		}
+	}
+	ui.moveCursor(0)
+}
+
+func (ui *tui) moveCursor(delta int) {
+	if len(ui.rows) == 0 {
+		ui.cursor = 0
+		return
+	}
+
+	cursor := ui.cursor + delta
+	for cursor >= 0 && cursor < len(ui.rows) && ui.rows[cursor].taskId == 0 {
+		if delta < 0 {
+			cursor--
+		} else {
+			cursor++
+		}
+	}
+
+	if cursor >= 0 && cursor < len(ui.rows) {
+		ui.cursor = cursor
+	} else if ui.cursor >= len(ui.rows) {
+		ui.cursor = len(ui.rows) - 1
+	}
+}
+
+func (ui *tui) selectedTask() *todoist.Task {
+	if ui.cursor < 0 || ui.cursor >= len(ui.rows) {
+		return nil
+	}
+
+	return ui.findTask(ui.rows[ui.cursor].taskId)
+}
+
+func (ui *tui) findTask(taskId int) *todoist.Task {
+	for i := range ui.tasks {
+		if ui.tasks[i].Id == taskId {
+			return &ui.tasks[i]
+		}
+	}
+
+	return nil
+}
+
+// Tasks missing from the list, such as ones moved away by a refresh, are not added back.
+func (ui *tui) replaceTask(task todoist.Task) {
+	if current := ui.findTask(task.Id); current != nil {
+		*current = task
+	}
+}
+
+// restoreTasks puts back tasks removed optimistically, unless a refresh already did.
+func (ui *tui) restoreTasks(tasks []todoist.Task) {
+	for _, task := range tasks {
+		if ui.findTask(task.Id) == nil {
+			ui.tasks = append(ui.tasks, task)
+		}
+	}
+}
+
+func (ui *tui) descendants(taskId int) []int {
+	ids := make([]int, 0)
+	for _, task := range ui.tasks {
+		if task.ParentId == taskId {
+			ids = append(ids, task.Id)
+			ids = append(ids, ui.descendants(task.Id)...)
+		}
+	}
+
+	return ids
+}
+
+// Subtasks are closed and moved together with their parent.
+func (ui *tui) removeTasks(taskId int) (removed []todoist.Task) {
+	ids := map[int]bool{taskId: true}
+	for _, id := range ui.descendants(taskId) {
+		ids[id] = true
+	}
+
+	kept := make([]todoist.Task, 0, len(ui.tasks))
+	for _, task := range ui.tasks {
+		if ids[task.Id] {
+			removed = append(removed, task)
+		} else {
+			kept = append(kept, task)
+		}
+	}
+	ui.tasks = kept
+
+	return
+}
+
+// endregion
+
+// region Render
+
+func (ui *tui) render(prompt string) {
+	height, width := ui.height, ui.width
+
+	lines := make([]string, 0, height)
+	var title string
+	cursor := 0
+
+	if ui.project == nil {
+		title = "Projects"
+		for _, node := range ui.projects {
+			lines = append(lines, strings.Repeat("  ", node.Depth())+node.Name)
+		}
+		cursor = ui.projectCursor
+	} else {
+		title = ui.project.Path()
+		for _, row := range ui.rows {
+			if row.taskId == 0 {
+				lines = append(lines, "\x1b[1m"+row.header+"\x1b[22m")
+				continue
+			}
+
+			if task := ui.findTask(row.taskId); task != nil {
+				lines = append(lines, strings.Repeat("  ", row.depth)+ui.formatTask(task))
+			}
+		}
+		cursor = ui.cursor
+	}
+
+	footer := prompt
+	if footer == "" {
+		footer = ui.status
+		if ui.pending != 0 {
+			footer = strconv.Itoa(ui.pending) + " pending  " + footer
+		}
+		if footer == "" {
+			footer = tuiHelp
+		}
+	}
+
+	visible := height - 3
+	if visible < 1 {
+		visible = 1
+	}
+
+	offset := 0
+	if cursor >= visible {
+		offset = cursor - visible + 1
+	}
+
+	_, _ = ui.out.WriteString("\x1b[H\x1b[2J\x1b[7m " + truncate(title, width-2) + " \x1b[27m\r\n\r\n")
+	for i := offset; i < len(lines) && i < offset+visible; i++ {
+		line := truncate(lines[i], width)
+		if i == cursor {
+			line = "\x1b[7m" + line + "\x1b[27m"
+		}
+		_, _ = ui.out.WriteString(line + "\r\n")
+	}
+
+	_, _ = ui.out.WriteString(fmt.Sprintf("\x1b[%d;1H%s", height, truncate(footer, width)))
+	_ = ui.out.Flush()
+}
+
+func (ui *tui) formatTask(task *todoist.Task) string {
+	line := strings.Builder{}
+	line.WriteString("[ ] ")
+	line.WriteString(task.Content)
+
+	if task.Priority > 1 {
+		line.WriteString(" p" + strconv.Itoa(5-task.Priority))
+	}
+
+	if task.Due.String != "" {
+		line.WriteString(" (" + task.Due.String + ")")
+	} else if task.Due.Date != "" {
+		line.WriteString(" (" + task.Due.Date + ")")
+	}
+
+	for _, labelId := range task.LabelIds {
+		line.WriteString(" @" + ui.labels[labelId])
+	}
+
+	if task.CommentCount != 0 {
+		line.WriteString(" [" + strconv.Itoa(task.CommentCount) + "]")
+	}
+
+	return line.String()
+}
+
+// truncate counts runes, ignoring escape sequences, which is close enough for headers.
+func truncate(text string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(text) <= width || strings.Contains(text, "\x1b") {
+		return text
+	}
+
+	runes := []rune(text)
+
+	return string(runes[:width-1]) + "…"
+}
+
+// endregion
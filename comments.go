@@ -68,9 +68,9 @@ func (p *GetCommentsParams) WithTaskId(taskId int) *GetCommentsParams {
 	return p
 }
 
-func (t *Todoist) GetComments(ctx context.Context, params *GetCommentsParams) (sections []Section, err error) {
-	sections = make([]Section, 0)
-	err = t.request(ctx, http.MethodGet, CommentsEndpoint, *params, nil, &sections)
+func (t *Todoist) GetComments(ctx context.Context, params *GetCommentsParams) (comments []Comment, err error) {
+	comments = make([]Comment, 0)
+	err = t.request(ctx, http.MethodGet, CommentsEndpoint, *params, nil, &comments)
 
 	return
 }
@@ -0,0 +1,298 @@
+package todoist
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const CompletedTasksEndpoint = "completed/get_all"
+const ItemEndpoint = "items/get"
+
+// The completed tasks endpoint returns at most 200 tasks per page.
+const CompletedTasksPageSize = 200
+
+type CompletedTask struct {
+	Task
+	CompletedDate string `json:"completed_date"`
+	Completer     int    `json:"completer"`
+}
+
+type completedTasksPage struct {
+	Items []struct {
+		Id            int       `json:"id"`
+		TaskId        int       `json:"task_id"`
+		UserId        int       `json:"user_id"`
+		ProjectId     int       `json:"project_id"`
+		SectionId     int       `json:"section_id"`
+		Content       string    `json:"content"`
+		CompletedDate string    `json:"completed_date"`
+		NoteCount     int       `json:"note_count"`
+		ItemObject    *syncItem `json:"item_object"`
+	} `json:"items"`
+}
+
+// syncItem is a task as returned by the Sync API.
+type syncItem struct {
+	Id             int      `json:"id"`
+	ProjectId      int      `json:"project_id"`
+	SectionId      int      `json:"section_id"`
+	ParentId       int      `json:"parent_id"`
+	Content        string   `json:"content"`
+	Description    string   `json:"description"`
+	Checked        syncBool `json:"checked"`
+	Labels         []int    `json:"labels"`
+	ChildOrder     int      `json:"child_order"`
+	Priority       int      `json:"priority"`
+	Due            *Due     `json:"due"`
+	ResponsibleUid int      `json:"responsible_uid"`
+	AssignedByUid  int      `json:"assigned_by_uid"`
+}
+
+// syncBool accepts both booleans and the 0 and 1 integers used by older Sync API versions.
+type syncBool bool
+
+func (b *syncBool) UnmarshalJSON(data []byte) error {
+	*b = string(data) == "true" || string(data) == "1"
+	return nil
+}
+
+func (i *syncItem) task() Task {
+	task := Task{
+		Id:          i.Id,
+		ProjectId:   i.ProjectId,
+		SectionId:   i.SectionId,
+		Content:     i.Content,
+		Description: i.Description,
+		Completed:   bool(i.Checked),
+		LabelIds:    i.Labels,
+		ParentId:    i.ParentId,
+		Order:       i.ChildOrder,
+		Priority:    i.Priority,
+		Url:         "https://todoist.com/showTask?id=" + strconv.Itoa(i.Id),
+		Assignee:    i.ResponsibleUid,
+		Assigner:    i.AssignedByUid,
+	}
+
+	if task.LabelIds == nil {
+		task.LabelIds = make([]int, 0)
+	}
+
+	if i.Due != nil {
+		task.Due = *i.Due
+	}
+
+	return task
+}
+
+// region GetCompletedTasks
+
+type GetCompletedTasksParams map[string]string
+
+//goland:noinspection GoUnusedExportedFunction
+func MakeGetCompletedTasksParams() *GetCompletedTasksParams {
+	params := make(GetCompletedTasksParams)
+	return &params
+}
+
+func (p *GetCompletedTasksParams) WithProjectId(projectId int) *GetCompletedTasksParams {
+	if projectId != 0 {
+		(*p)["project_id"] = strconv.Itoa(projectId)
+	}
+
+	return p
+}
+
+func (p *GetCompletedTasksParams) WithSectionId(sectionId int) *GetCompletedTasksParams {
+	if sectionId != 0 {
+		(*p)["section_id"] = strconv.Itoa(sectionId)
+	}
+
+	return p
+}
+
+func (p *GetCompletedTasksParams) WithParentId(parentId int) *GetCompletedTasksParams {
+	if parentId != 0 {
+		(*p)["parent_id"] = strconv.Itoa(parentId)
+	}
+
+	return p
+}
+
+func (p *GetCompletedTasksParams) WithSince(since time.Time) *GetCompletedTasksParams {
+	if !since.IsZero() {
+		(*p)["since"] = since.UTC().Format(syncTimeLayout)
+	}
+
+	return p
+}
+
+func (p *GetCompletedTasksParams) WithUntil(until time.Time) *GetCompletedTasksParams {
+	if !until.IsZero() {
+		(*p)["until"] = until.UTC().Format(syncTimeLayout)
+	}
+
+	return p
+}
+
+// WithLimit caps the total number of returned tasks. Pages are fetched as needed.
+func (p *GetCompletedTasksParams) WithLimit(limit int) *GetCompletedTasksParams {
+	if limit != 0 {
+		(*p)["limit"] = strconv.Itoa(limit)
+	}
+
+	return p
+}
+
+// WithDetails fetches the full task of every completed task that the archive
+// returns without one, with a request per task. The parent filter needs them.
+func (p *GetCompletedTasksParams) WithDetails(details bool) *GetCompletedTasksParams {
+	if details {
+		(*p)["details"] = "true"
+	}
+
+	return p
+}
+
+// GetCompletedTasks returns the completed tasks, most recent first. The archive
+// is asked for the full tasks, which fill in the fields it does not keep, such
+// as the parent, labels and due date. The API only filters by project and
+// time, so the section and parent filters are applied locally.
+func (t *Todoist) GetCompletedTasks(ctx context.Context, params *GetCompletedTasksParams) (tasks []CompletedTask, err error) {
+	query := make(map[string]string, len(*params)+1)
+	for key, value := range *params {
+		query[key] = value
+	}
+
+	sectionId, _ := strconv.Atoi(query["section_id"])
+	parentId, _ := strconv.Atoi(query["parent_id"])
+	limit, _ := strconv.Atoi(query["limit"])
+	details := query["details"] == "true" || parentId != 0
+	delete(query, "section_id")
+	delete(query, "parent_id")
+	delete(query, "details")
+	query["annotate_items"] = "true"
+
+	tasks = make([]CompletedTask, 0)
+	for offset := 0; limit == 0 || len(tasks) < limit; {
+		// Without client side filters there is no need to fetch more than the limit.
+		pageSize := CompletedTasksPageSize
+		if limit != 0 && sectionId == 0 && parentId == 0 && limit-len(tasks) < pageSize {
+			pageSize = limit - len(tasks)
+		}
+
+		query["limit"] = strconv.Itoa(pageSize)
+		query["offset"] = strconv.Itoa(offset)
+
+		page := new(completedTasksPage)
+		if err = t.requestUrl(ctx, http.MethodGet, SyncBaseUrl, CompletedTasksEndpoint, query, nil, page); err != nil {
+			return
+		}
+
+		found := make([]CompletedTask, len(page.Items))
+		missing := make([]int, 0)
+		for i, item := range page.Items {
+			if item.ItemObject != nil {
+				task := item.ItemObject.task()
+				task.Completed = true
+				task.CommentCount = item.NoteCount
+				found[i] = CompletedTask{Task: task, CompletedDate: item.CompletedDate, Completer: item.UserId}
+				continue
+			}
+
+			missing = append(missing, i)
+			found[i] = CompletedTask{
+				Task: Task{
+					Id:           item.TaskId,
+					ProjectId:    item.ProjectId,
+					SectionId:    item.SectionId,
+					Content:      item.Content,
+					Completed:    true,
+					LabelIds:     make([]int, 0),
+					CommentCount: item.NoteCount,
+				},
+				CompletedDate: item.CompletedDate,
+				Completer:     item.UserId,
+			}
+		}
+
+		if details && len(missing) != 0 {
+			if err = t.getCompletedTaskDetails(ctx, found, missing); err != nil {
+				return
+			}
+		}
+
+		for _, task := range found {
+			if sectionId != 0 && task.SectionId != sectionId || parentId != 0 && task.ParentId != parentId {
+				continue
+			}
+
+			if limit != 0 && len(tasks) == limit {
+				break
+			}
+
+			tasks = append(tasks, task)
+		}
+
+		if len(page.Items) < pageSize {
+			break
+		}
+		offset += len(page.Items)
+	}
+
+	return
+}
+
+// getCompletedTaskDetails fetches the tasks at the missing indexes once per
+// task id, since a recurring task is completed many times. Tasks deleted since
+// their completion keep the fields known to the archive.
+func (t *Todoist) getCompletedTaskDetails(ctx context.Context, tasks []CompletedTask, missing []int) error {
+	indexes := make(map[int][]int)
+	ids := make([]int, 0)
+	for _, i := range missing {
+		if _, ok := indexes[tasks[i].Id]; !ok {
+			ids = append(ids, tasks[i].Id)
+		}
+		indexes[tasks[i].Id] = append(indexes[tasks[i].Id], i)
+	}
+
+	return t.runBulk(ctx, len(ids), nil, func(ctx context.Context, n int) (err error) {
+		var item *syncItem
+		if item, err = t.getSyncItem(ctx, ids[n]); err != nil {
+			if IsNotFound(err) {
+				return nil
+			}
+
+			return
+		}
+
+		for _, i := range indexes[ids[n]] {
+			task := item.task()
+			task.Completed = true
+			task.CommentCount = tasks[i].CommentCount
+			tasks[i].Task = task
+		}
+
+		return
+	})
+}
+
+func (t *Todoist) getSyncItem(ctx context.Context, itemId int) (item *syncItem, err error) {
+	res := &struct {
+		Item *syncItem `json:"item"`
+	}{}
+
+	params := map[string]string{"item_id": strconv.Itoa(itemId), "all_data": "false"}
+	if err = t.requestUrl(ctx, http.MethodGet, SyncBaseUrl, ItemEndpoint, params, nil, res); err != nil {
+		return
+	}
+
+	if res.Item == nil {
+		return nil, &ResponseError{StatusCode: http.StatusNotFound, Status: "404 Not Found"}
+	}
+
+	return res.Item, nil
+}
+
+// endregion
@@ -0,0 +1,74 @@
+package todoist
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// completedStub serves a page with an annotated task and a task completed twice
+// without its task object, and counts the item requests.
+func completedStub(t *testing.T, itemRequests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/sync/v8/completed/get_all":
+			if r.URL.Query().Get("annotate_items") != "true" {
+				t.Errorf("completed/get_all: annotate_items not requested")
+			}
+			_, _ = w.Write([]byte(`{"items": [
+				{"task_id": 1, "project_id": 10, "content": "Annotated", "completed_date": "2024-05-15T09:00:00Z", "item_object": {"id": 1, "project_id": 10, "parent_id": 5, "content": "Annotated", "labels": [3]}},
+				{"task_id": 2, "project_id": 10, "content": "Recurring", "completed_date": "2024-05-14T09:00:00Z"},
+				{"task_id": 2, "project_id": 10, "content": "Recurring", "completed_date": "2024-05-13T09:00:00Z"}
+			]}`))
+		case "/sync/v8/items/get":
+			*itemRequests++
+			_, _ = w.Write([]byte(`{"item": {"id": 2, "project_id": 10, "parent_id": 5, "content": "Recurring", "labels": []}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestGetCompletedTasks(t *testing.T) {
+	tests := []struct {
+		name         string
+		params       *GetCompletedTasksParams
+		wantTasks    int
+		wantParents  []int
+		wantRequests int
+	}{
+		{"archive only", MakeGetCompletedTasksParams(), 3, []int{5, 0, 0}, 0},
+		{"details", MakeGetCompletedTasksParams().WithDetails(true), 3, []int{5, 5, 5}, 1},
+		{"parent filter", MakeGetCompletedTasksParams().WithParentId(5), 3, []int{5, 5, 5}, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			itemRequests := 0
+			server := completedStub(t, &itemRequests)
+			defer server.Close()
+
+			client := New(&Opts{Token: "secret", Client: &http.Client{Transport: rewriteHost(http.DefaultTransport, server.URL)}})
+			tasks, err := client.GetCompletedTasks(context.Background(), test.params)
+			if err != nil {
+				t.Fatalf("GetCompletedTasks: %s", err)
+			}
+
+			if len(tasks) != test.wantTasks {
+				t.Fatalf("got %d tasks, want %d", len(tasks), test.wantTasks)
+			}
+			for i, task := range tasks {
+				if task.ParentId != test.wantParents[i] || !task.Completed {
+					t.Errorf("task %d: got parent %d, completed %v, want parent %d", i, task.ParentId, task.Completed, test.wantParents[i])
+				}
+			}
+			if itemRequests != test.wantRequests {
+				t.Errorf("got %d item requests, want %d", itemRequests, test.wantRequests)
+			}
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package todoist
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	CSVSection = "section"
+	CSVTask    = "task"
+	CSVNote    = "note"
+)
+
+var CSVHeader = []string{"TYPE", "CONTENT", "DESCRIPTION", "PRIORITY", "INDENT", "AUTHOR", "RESPONSIBLE", "DATE", "DATE_LANG", "TIMEZONE"}
+
+var csvUserPattern = regexp.MustCompile(`^(.*?)\s*\((\d+)\)$`)
+
+// region ExportProjectCSV
+
+func (t *Todoist) ExportProjectCSV(ctx context.Context, projectId int, w io.Writer) (err error) {
+	var sections []Section
+	if sections, err = t.GetSections(ctx, MakeGetSectionsParams().WithProjectId(projectId)); err != nil {
+		return
+	}
+
+	var tasks []Task
+	if tasks, err = t.GetTasks(ctx, MakeGetTasksParams().WithProjectId(projectId)); err != nil {
+		return
+	}
+
+	var collaborators []Collaborator
+	if collaborators, err = t.GetCollaborators(ctx, projectId); err != nil {
+		return
+	}
+
+	names := make(map[int]string, len(collaborators))
+	for _, collaborator := range collaborators {
+		names[collaborator.Id] = collaborator.Name
+	}
+
+	writer := csv.NewWriter(w)
+	if err = writer.Write(CSVHeader); err != nil {
+		return
+	}
+
+	var comments []Comment
+	if comments, err = t.GetComments(ctx, MakeGetCommentsParams().WithProjectId(projectId)); err != nil {
+		return
+	}
+
+	for _, comment := range comments {
+		if err = writer.Write([]string{CSVNote, comment.Content, "", "", "", "", "", "", "", ""}); err != nil {
+			return
+		}
+	}
+
+	children := make(map[int][]Task)
+	for _, task := range tasks {
+		children[task.ParentId] = append(children[task.ParentId], task)
+	}
+
+	for _, siblings := range children {
+		sort.SliceStable(siblings, func(i, j int) bool {
+			return siblings[i].Order < siblings[j].Order
+		})
+	}
+
+	// AUTHOR is the creator of a task, which the REST API doesn't return, so
+	// the column is left empty.
+	var dateLang string
+	var writeTask func(task Task, indent int) error
+	writeTask = func(task Task, indent int) (err error) {
+		record := []string{CSVTask, task.Content, task.Description, strconv.Itoa(csvPriority(task.Priority)), strconv.Itoa(indent), "", "", task.Due.String, "", task.Due.Timezone}
+		if task.Assignee != 0 {
+			record[6] = names[task.Assignee] + " (" + strconv.Itoa(task.Assignee) + ")"
+		}
+		if task.Due.String != "" {
+			if dateLang == "" {
+				if dateLang, err = t.csvDateLang(ctx); err != nil {
+					return
+				}
+			}
+			record[8] = dateLang
+		}
+
+		if err = writer.Write(record); err != nil {
+			return
+		}
+
+		if task.CommentCount != 0 {
+			var comments []Comment
+			if comments, err = t.GetComments(ctx, MakeGetCommentsParams().WithTaskId(task.Id)); err != nil {
+				return
+			}
+
+			for _, comment := range comments {
+				if err = writer.Write([]string{CSVNote, comment.Content, "", "", "", "", "", "", "", ""}); err != nil {
+					return
+				}
+			}
+		}
+
+		for _, child := range children[task.Id] {
+			if err = writeTask(child, indent+1); err != nil {
+				return
+			}
+		}
+
+		return
+	}
+
+	known := make(map[int]bool, len(tasks))
+	for _, task := range tasks {
+		known[task.Id] = true
+	}
+
+	writeSection := func(sectionId int) (err error) {
+		for _, task := range tasks {
+			if task.SectionId == sectionId && (task.ParentId == 0 || !known[task.ParentId]) {
+				if err = writeTask(task, 1); err != nil {
+					return
+				}
+			}
+		}
+
+		return
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].Order < tasks[j].Order
+	})
+
+	if err = writeSection(0); err != nil {
+		return
+	}
+
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sections[i].Order < sections[j].Order
+	})
+
+	for _, section := range sections {
+		if err = writer.Write([]string{CSVSection, section.Name, "", "", "", "", "", "", "", ""}); err != nil {
+			return
+		}
+
+		if err = writeSection(section.Id); err != nil {
+			return
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// Due strings are written in the user's language, as the app does.
+func (t *Todoist) csvDateLang(ctx context.Context) (lang string, err error) {
+	var user *User
+	if user, err = t.GetUser(ctx); err != nil {
+		return
+	}
+
+	if user.Lang == "" {
+		return "en", nil
+	}
+
+	return user.Lang, nil
+}
+
+// CSV priorities follow the UI, where 1 is the most urgent one.
+func csvPriority(priority int) int {
+	if priority < 1 || priority > 4 {
+		return 4
+	}
+
+	return 5 - priority
+}
+
+// endregion
+
+// region ImportProjectCSV
+
+func (t *Todoist) ImportProjectCSV(ctx context.Context, projectId int, r io.Reader) (err error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var header []string
+	if header, err = reader.Read(); err != nil {
+		return
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	if _, ok := columns["TYPE"]; !ok {
+		return errors.New("missing TYPE column")
+	}
+
+	var collaborators []Collaborator
+	sectionId := 0
+	parents := make([]int, 0)
+
+	for line := 2; ; line++ {
+		var record []string
+		if record, err = reader.Read(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+
+			return ""
+		}
+
+		switch strings.ToLower(field("TYPE")) {
+		case CSVSection:
+			var section *Section
+			if section, err = t.AddSection(ctx, MakeAddSectionParams().WithProjectId(projectId).WithName(field("CONTENT"))); err != nil {
+				return fmt.Errorf("line %d: %w", line, err)
+			}
+
+			sectionId = section.Id
+			parents = parents[:0]
+		case CSVTask:
+			indent := 1
+			if value := field("INDENT"); value != "" {
+				if indent, err = strconv.Atoi(value); err != nil || indent < 1 {
+					return fmt.Errorf("line %d: invalid indent %q", line, value)
+				}
+			}
+
+			if indent > len(parents)+1 {
+				indent = len(parents) + 1
+			}
+			parents = parents[:indent-1]
+
+			params := MakeAddTaskParams().
+				WithContent(field("CONTENT")).
+				WithDescription(field("DESCRIPTION")).
+				WithProjectId(projectId).
+				WithDueString(field("DATE")).
+				WithDueLang(field("DATE_LANG"))
+
+			if len(parents) != 0 {
+				params.WithParentId(parents[len(parents)-1])
+			} else {
+				params.WithSectionId(sectionId)
+			}
+
+			if value := field("PRIORITY"); value != "" {
+				var priority int
+				if priority, err = strconv.Atoi(value); err != nil {
+					return fmt.Errorf("line %d: invalid priority %q", line, value)
+				}
+				params.WithPriority(csvPriority(priority))
+			}
+
+			if responsible := field("RESPONSIBLE"); responsible != "" {
+				if collaborators == nil {
+					if collaborators, err = t.GetCollaborators(ctx, projectId); err != nil {
+						return
+					}
+				}
+
+				params.WithAssignee(matchCollaborator(collaborators, responsible))
+			}
+
+			var task *Task
+			if task, err = t.AddTask(ctx, params); err != nil {
+				return fmt.Errorf("line %d: %w", line, err)
+			}
+
+			parents = append(parents, task.Id)
+		case CSVNote:
+			params := MakeAddCommentParams().WithContent(field("CONTENT"))
+			if len(parents) != 0 {
+				params.WithTaskId(parents[len(parents)-1])
+			} else {
+				params.WithProjectId(projectId)
+			}
+
+			if _, err = t.AddComment(ctx, params); err != nil {
+				return fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+	}
+}
+
+// Users are written as "Name (id)", but plain names and emails are accepted as well.
+func matchCollaborator(collaborators []Collaborator, user string) int {
+	name := user
+	if match := csvUserPattern.FindStringSubmatch(user); match != nil {
+		id, _ := strconv.Atoi(match[2])
+		for _, collaborator := range collaborators {
+			if collaborator.Id == id {
+				return id
+			}
+		}
+
+		name = match[1]
+	}
+
+	for _, collaborator := range collaborators {
+		if strings.EqualFold(collaborator.Email, name) || FoldName(collaborator.Name) == FoldName(name) {
+			return collaborator.Id
+		}
+	}
+
+	return 0
+}
+
+// endregion
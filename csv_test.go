@@ -0,0 +1,138 @@
+package todoist
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestExportProjectCSV(t *testing.T) {
+	stub := newAPIStub(t)
+	stub.resources["user"] = map[string]interface{}{"id": 8, "lang": "de"}
+	stub.responses["/rest/v1/projects/1/collaborators"] = []map[string]interface{}{
+		{"id": 7, "name": "Ann", "email": "ann@example.com"},
+		{"id": 8, "name": "Bob", "email": "bob@example.com"},
+	}
+
+	stub.add(SectionsEndpoint, map[string]interface{}{"id": 10, "project_id": 1, "name": "Later", "order": 1})
+	stub.add(TasksEndpoint, map[string]interface{}{"id": 22, "project_id": 1, "section_id": 10, "content": "Archive", "priority": 1, "order": 1})
+	stub.add(TasksEndpoint, map[string]interface{}{"id": 21, "project_id": 1, "parent_id": 20, "content": "Child", "priority": 1, "order": 1})
+	stub.add(TasksEndpoint, map[string]interface{}{
+		"id": 20, "project_id": 1, "content": "Plan", "description": "First step", "priority": 4, "order": 1,
+		"assignee": 7, "assigner": 8, "comment_count": 1, "due": map[string]interface{}{"string": "every day", "timezone": "Europe/Berlin"},
+	})
+	stub.add(CommentsEndpoint, map[string]interface{}{"id": 30, "project_id": 1, "content": "Project note"})
+	stub.add(CommentsEndpoint, map[string]interface{}{"id": 31, "task_id": 20, "content": "Task note"})
+
+	var buf bytes.Buffer
+	if err := stub.client().ExportProjectCSV(context.Background(), 1, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join([]string{
+		"TYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE,DATE_LANG,TIMEZONE",
+		"note,Project note,,,,,,,,",
+		"task,Plan,First step,1,1,,Ann (7),every day,de,Europe/Berlin",
+		"note,Task note,,,,,,,,",
+		"task,Child,,4,2,,,,,",
+		"section,Later,,,,,,,,",
+		"task,Archive,,4,1,,,,,",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestImportProjectCSV(t *testing.T) {
+	stub := newAPIStub(t)
+	stub.responses["/rest/v1/projects/1/collaborators"] = []map[string]interface{}{
+		{"id": 7, "name": "Ann", "email": "ann@example.com"},
+	}
+
+	input := strings.Join([]string{
+		"\ufeffTYPE,CONTENT,PRIORITY,INDENT,RESPONSIBLE,DATE,DATE_LANG",
+		"note,Project note,,,,,",
+		"task,Plan,1,1,Ann (7),every day,de",
+		"task,Child,,3,ann@example.com,,",
+		"note,Task note,,,,,",
+		"section,Later,,,,,",
+		"task,Archive,4,1,,,",
+	}, "\n")
+
+	if err := stub.client().ImportProjectCSV(context.Background(), 1, strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+
+	tasks := stub.objects(TasksEndpoint)
+	if len(tasks) != 3 {
+		t.Fatalf("got %d tasks, want 3", len(tasks))
+	}
+
+	plan, child, archive := tasks[0], tasks[1], tasks[2]
+	tests := []struct {
+		name string
+		got  interface{}
+		want string
+	}{
+		{"plan priority", plan["priority"], "4"},
+		{"plan assignee", plan["assignee"], "7"},
+		{"plan due", plan["due_string"], "every day"},
+		{"plan due lang", plan["due_lang"], "de"},
+		{"child parent", child["parent_id"], stubString(plan["id"])},
+		{"child assignee", child["assignee"], "7"},
+		{"archive priority", archive["priority"], "1"},
+		{"archive section", archive["section_id"], stubString(stub.objects(SectionsEndpoint)[0]["id"])},
+	}
+
+	for _, test := range tests {
+		if got := stubString(test.got); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+
+	comments := stub.objects(CommentsEndpoint)
+	if len(comments) != 2 {
+		t.Fatalf("got %d comments, want 2", len(comments))
+	}
+	if got := stubString(comments[0]["project_id"]); got != "1" {
+		t.Errorf("project note: got project %s, want 1", got)
+	}
+	if got, want := stubString(comments[1]["task_id"]), stubString(child["id"]); got != want {
+		t.Errorf("task note: got task %s, want %s", got, want)
+	}
+}
+
+func TestImportProjectCSVRequiresType(t *testing.T) {
+	stub := newAPIStub(t)
+
+	if err := stub.client().ImportProjectCSV(context.Background(), 1, strings.NewReader("CONTENT\nPlan\n")); err == nil {
+		t.Error("got no error for a file without a TYPE column")
+	}
+}
+
+func TestMatchCollaborator(t *testing.T) {
+	collaborators := []Collaborator{
+		{Id: 7, Name: "Ann Smith", Email: "ann@example.com"},
+		{Id: 8, Name: "Bob", Email: "bob@example.com"},
+	}
+
+	tests := []struct {
+		user string
+		want int
+	}{
+		{"Ann Smith (7)", 7},
+		{"Someone (8)", 8},
+		{"Bob (99)", 8},
+		{"ann smith", 7},
+		{"BOB@example.com", 8},
+		{"Carol", 0},
+	}
+
+	for _, test := range tests {
+		if got := matchCollaborator(collaborators, test.user); got != test.want {
+			t.Errorf("%q: got %d, want %d", test.user, got, test.want)
+		}
+	}
+}
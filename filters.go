@@ -0,0 +1,225 @@
+package todoist
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+type Filter struct {
+	Id       int    `json:"id"`
+	Name     string `json:"name"`
+	Query    string `json:"query"`
+	Color    int    `json:"color"`
+	Order    int    `json:"item_order"`
+	Favorite bool   `json:"is_favorite"`
+}
+
+// syncFilter is a filter as returned by the Sync API.
+type syncFilter struct {
+	Id         int      `json:"id"`
+	Name       string   `json:"name"`
+	Query      string   `json:"query"`
+	Color      int      `json:"color"`
+	ItemOrder  int      `json:"item_order"`
+	IsFavorite syncBool `json:"is_favorite"`
+	IsDeleted  syncBool `json:"is_deleted"`
+}
+
+// region GetFilters
+
+func (t *Todoist) GetFilters(ctx context.Context) (filters []Filter, err error) {
+	res := &struct {
+		Filters []syncFilter `json:"filters"`
+	}{}
+
+	if err = t.syncRead(WithOperation(ctx, "GetFilters"), []string{"filters"}, res); err != nil {
+		return
+	}
+
+	filters = make([]Filter, 0, len(res.Filters))
+	for _, filter := range res.Filters {
+		if filter.IsDeleted {
+			continue
+		}
+
+		filters = append(filters, Filter{
+			Id:       filter.Id,
+			Name:     filter.Name,
+			Query:    filter.Query,
+			Color:    filter.Color,
+			Order:    filter.ItemOrder,
+			Favorite: bool(filter.IsFavorite),
+		})
+	}
+
+	return
+}
+
+// endregion
+
+// region AddFilter
+
+type AddFilterParams map[string]interface{}
+
+//goland:noinspection GoUnusedExportedFunction
+func MakeAddFilterParams() *AddFilterParams {
+	params := make(AddFilterParams)
+	return &params
+}
+
+func (p *AddFilterParams) WithName(name string) *AddFilterParams {
+	if name != "" {
+		(*p)["name"] = name
+	}
+
+	return p
+}
+
+func (p *AddFilterParams) WithQuery(query string) *AddFilterParams {
+	if query != "" {
+		(*p)["query"] = query
+	}
+
+	return p
+}
+
+func (p *AddFilterParams) WithColor(color int) *AddFilterParams {
+	if color != 0 {
+		(*p)["color"] = color
+	}
+
+	return p
+}
+
+func (p *AddFilterParams) WithOrder(order int) *AddFilterParams {
+	if order != 0 {
+		(*p)["item_order"] = order
+	}
+
+	return p
+}
+
+func (p *AddFilterParams) WithFavorite(favorite bool) *AddFilterParams {
+	(*p)["is_favorite"] = favorite
+	return p
+}
+
+func (t *Todoist) AddFilter(ctx context.Context, params *AddFilterParams) (filter *Filter, err error) {
+	var id int
+	if id, err = t.syncAdd(WithOperation(ctx, "AddFilter"), "filter_add", params); err != nil {
+		return
+	}
+
+	// The filter is read back, since the server fills in the color and order.
+	var filters []Filter
+	if filters, err = t.GetFilters(ctx); err != nil {
+		return
+	}
+
+	for i := range filters {
+		if filters[i].Id == id {
+			return &filters[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("filter_add: missing filter %d", id)
+}
+
+// endregion
+
+// region UpdateFilter
+
+type UpdateFilterParams map[string]interface{}
+
+//goland:noinspection GoUnusedExportedFunction
+func MakeUpdateFilterParams() *UpdateFilterParams {
+	params := make(UpdateFilterParams)
+	return &params
+}
+
+func (p *UpdateFilterParams) WithName(name string) *UpdateFilterParams {
+	if name != "" {
+		(*p)["name"] = name
+	}
+
+	return p
+}
+
+func (p *UpdateFilterParams) WithQuery(query string) *UpdateFilterParams {
+	if query != "" {
+		(*p)["query"] = query
+	}
+
+	return p
+}
+
+func (p *UpdateFilterParams) WithColor(color int) *UpdateFilterParams {
+	if color != 0 {
+		(*p)["color"] = color
+	}
+
+	return p
+}
+
+func (p *UpdateFilterParams) WithOrder(order int) *UpdateFilterParams {
+	if order != 0 {
+		(*p)["item_order"] = order
+	}
+
+	return p
+}
+
+func (p *UpdateFilterParams) WithFavorite(favorite bool) *UpdateFilterParams {
+	(*p)["is_favorite"] = favorite
+	return p
+}
+
+func (t *Todoist) UpdateFilter(ctx context.Context, filterId int, params *UpdateFilterParams) (err error) {
+	args := make(map[string]interface{}, len(*params)+1)
+	for key, value := range *params {
+		args[key] = value
+	}
+	args["id"] = filterId
+
+	_, err = t.Sync(WithOperation(ctx, "UpdateFilter"), MakeSyncCommand("filter_update", args))
+
+	return
+}
+
+// endregion
+
+// region DeleteFilter
+
+func (t *Todoist) DeleteFilter(ctx context.Context, filterId int) (err error) {
+	_, err = t.Sync(WithOperation(ctx, "DeleteFilter"), MakeSyncCommand("filter_delete", map[string]interface{}{"id": filterId}))
+
+	return
+}
+
+// endregion
+
+// region ReorderFilters
+
+// ReorderFilters orders the filters as listed in filterIds.
+func (t *Todoist) ReorderFilters(ctx context.Context, filterIds []int) (err error) {
+	orders := make(map[string]int, len(filterIds))
+	for i, filterId := range filterIds {
+		orders[strconv.Itoa(filterId)] = i + 1
+	}
+
+	_, err = t.Sync(WithOperation(ctx, "ReorderFilters"), MakeSyncCommand("filter_update_orders", map[string]interface{}{"id_order_mapping": orders}))
+
+	return
+}
+
+// endregion
+
+// region GetFilterTasks
+
+// GetFilterTasks returns the active tasks matching the query of a saved filter.
+func (t *Todoist) GetFilterTasks(ctx context.Context, filter *Filter) (tasks []Task, err error) {
+	return t.GetTasks(ctx, MakeGetTasksParams().WithFilter(filter.Query))
+}
+
+// endregion
@@ -0,0 +1,106 @@
+package todoist
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestGetFilters(t *testing.T) {
+	stub := newAPIStub(t)
+	stub.resources["filters"] = []map[string]interface{}{
+		{"id": 1, "name": "Today", "query": "today", "color": 30, "item_order": 2, "is_favorite": 1},
+		{"id": 2, "name": "Old", "query": "overdue", "is_deleted": 1},
+	}
+
+	filters, err := stub.client().GetFilters(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Filter{{Id: 1, Name: "Today", Query: "today", Color: 30, Order: 2, Favorite: true}}
+	if !reflect.DeepEqual(filters, want) {
+		t.Errorf("got %+v, want %+v", filters, want)
+	}
+}
+
+func TestAddFilter(t *testing.T) {
+	stub := newAPIStub(t)
+	stub.resources["filters"] = []map[string]interface{}{
+		{"id": 101, "name": "Work", "query": "#Work & today", "color": 47, "item_order": 3, "is_favorite": false},
+	}
+
+	filter, err := stub.client().AddFilter(context.Background(), MakeAddFilterParams().WithName("Work").WithQuery("#Work & today"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Filter{Id: 101, Name: "Work", Query: "#Work & today", Color: 47, Order: 3}
+	if !reflect.DeepEqual(filter, want) {
+		t.Errorf("got %+v, want %+v", filter, want)
+	}
+
+	commands := stub.syncCommands()
+	if len(commands) != 1 || commands[0].Type != "filter_add" || commands[0].TempId == "" {
+		t.Errorf("got commands %+v, want a filter_add with a temp id", commands)
+	}
+}
+
+func TestAddFilterMissing(t *testing.T) {
+	stub := newAPIStub(t)
+
+	if _, err := stub.client().AddFilter(context.Background(), MakeAddFilterParams().WithName("Work")); err == nil {
+		t.Error("got no error for a filter missing from the read")
+	}
+}
+
+func TestFilterCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		run      func(ctx context.Context, client *Todoist) error
+		wantType string
+		wantArgs map[string]interface{}
+	}{
+		{
+			name: "update",
+			run: func(ctx context.Context, client *Todoist) error {
+				return client.UpdateFilter(ctx, 7, MakeUpdateFilterParams().WithName("Later").WithOrder(4).WithFavorite(true))
+			},
+			wantType: "filter_update",
+			wantArgs: map[string]interface{}{"id": 7.0, "name": "Later", "item_order": 4.0, "is_favorite": true},
+		},
+		{
+			name: "delete",
+			run: func(ctx context.Context, client *Todoist) error {
+				return client.DeleteFilter(ctx, 7)
+			},
+			wantType: "filter_delete",
+			wantArgs: map[string]interface{}{"id": 7.0},
+		},
+		{
+			name: "reorder",
+			run: func(ctx context.Context, client *Todoist) error {
+				return client.ReorderFilters(ctx, []int{9, 7})
+			},
+			wantType: "filter_update_orders",
+			wantArgs: map[string]interface{}{"id_order_mapping": map[string]interface{}{"9": 1.0, "7": 2.0}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stub := newAPIStub(t)
+			if err := test.run(context.Background(), stub.client()); err != nil {
+				t.Fatal(err)
+			}
+
+			commands := stub.syncCommands()
+			if len(commands) != 1 {
+				t.Fatalf("got %d commands, want 1", len(commands))
+			}
+			if commands[0].Type != test.wantType || !reflect.DeepEqual(commands[0].Args, test.wantArgs) {
+				t.Errorf("got %s %v, want %s %v", commands[0].Type, commands[0].Args, test.wantType, test.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,571 @@
+package todoist
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ICSTodo  = "VTODO"
+	ICSEvent = "VEVENT"
+)
+
+const ICSContentType = "text/calendar; charset=utf-8"
+
+const (
+	icsDateLayout     = "20060102"
+	icsDatetimeLayout = "20060102T150405"
+	icsLineLimit      = 75
+)
+
+type ICSOpts struct {
+	Component     string
+	Name          string
+	ProdId        string
+	EventDuration time.Duration
+}
+
+// region WriteICS
+
+// WriteICS writes the tasks with a due date as a calendar. Due times with a
+// time zone are written with its TZID and a VTIMEZONE listing its offset
+// changes over the years of the due dates, plus a few more for recurring tasks.
+func WriteICS(w io.Writer, tasks []Task, opts *ICSOpts) (err error) {
+	if opts == nil {
+		opts = new(ICSOpts)
+	}
+
+	component := opts.Component
+	if component == "" {
+		component = ICSTodo
+	}
+
+	prodId := opts.ProdId
+	if prodId == "" {
+		prodId = "-//temoon//todoist-api//EN"
+	}
+
+	duration := opts.EventDuration
+	if duration == 0 {
+		duration = time.Hour
+	}
+
+	writer := &icsWriter{w: bufio.NewWriter(w)}
+	writer.line("BEGIN", "VCALENDAR")
+	writer.line("VERSION", "2.0")
+	writer.line("PRODID", prodId)
+	writer.line("CALSCALE", "GREGORIAN")
+	if opts.Name != "" {
+		writer.line("X-WR-CALNAME", escapeICSText(opts.Name))
+	}
+
+	for _, zone := range collectICSZones(tasks) {
+		writeICSZone(writer, zone)
+	}
+
+	stamp := time.Now().UTC().Format(icsDatetimeLayout) + "Z"
+	for _, task := range tasks {
+		if task.Due.Date == "" && task.Due.Datetime == "" {
+			continue
+		}
+
+		writer.line("BEGIN", component)
+		writer.line("UID", ICSUid(task.Id))
+		writer.line("DTSTAMP", stamp)
+		writer.line("SUMMARY", escapeICSText(task.Content))
+		if task.Description != "" {
+			writer.line("DESCRIPTION", escapeICSText(task.Description))
+		}
+		if task.Url != "" {
+			writer.line("URL", task.Url)
+		}
+		if priority := icsPriority(task.Priority); priority != 0 {
+			writer.line("PRIORITY", strconv.Itoa(priority))
+		}
+
+		rule := ""
+		if task.Due.Recurring {
+			rule = DueStringToRRule(task.Due.String)
+		}
+
+		if err = writeICSDue(writer, component, task.Due, duration, rule != ""); err != nil {
+			return fmt.Errorf("task %d: %w", task.Id, err)
+		}
+
+		if rule != "" {
+			writer.line("RRULE", rule)
+		}
+
+		if component == ICSTodo {
+			if task.Completed {
+				writer.line("STATUS", "COMPLETED")
+			} else {
+				writer.line("STATUS", "NEEDS-ACTION")
+			}
+		}
+
+		writer.line("END", component)
+	}
+
+	writer.line("END", "VCALENDAR")
+
+	if writer.err != nil {
+		return writer.err
+	}
+
+	return writer.w.Flush()
+}
+
+// Recurring todos also get a DTSTART, which anchors the recurrence set.
+func writeICSDue(writer *icsWriter, component string, due Due, duration time.Duration, recurring bool) (err error) {
+	names := []string{"DTSTART"}
+	if component == ICSTodo {
+		names = []string{"DUE"}
+		if recurring {
+			names = append(names, "DTSTART")
+		}
+	}
+
+	if due.Datetime == "" {
+		var date time.Time
+		if date, err = time.Parse("2006-01-02", due.Date); err != nil {
+			return
+		}
+
+		for _, name := range names {
+			writer.line(name+";VALUE=DATE", date.Format(icsDateLayout))
+		}
+		if component == ICSEvent {
+			writer.line("DTEND;VALUE=DATE", date.AddDate(0, 0, 1).Format(icsDateLayout))
+		}
+
+		return
+	}
+
+	var datetime time.Time
+	floating := false
+	if datetime, err = time.Parse(time.RFC3339, due.Datetime); err != nil {
+		if datetime, err = time.Parse("2006-01-02T15:04:05", due.Datetime); err != nil {
+			return
+		}
+		floating = true
+	}
+
+	location := icsLocation(due)
+	format := func(value time.Time) (string, string) {
+		switch {
+		case floating:
+			return "", value.Format(icsDatetimeLayout)
+		case location != nil:
+			return ";TZID=" + location.String(), value.In(location).Format(icsDatetimeLayout)
+		default:
+			return "", value.UTC().Format(icsDatetimeLayout) + "Z"
+		}
+	}
+
+	params, value := format(datetime)
+	for _, name := range names {
+		writer.line(name+params, value)
+	}
+	if component == ICSEvent {
+		params, value = format(datetime.Add(duration))
+		writer.line("DTEND"+params, value)
+	}
+
+	return
+}
+
+// icsLocation returns the time zone of the due time, or nil when it is written in UTC.
+func icsLocation(due Due) *time.Location {
+	if due.Timezone == "" || strings.EqualFold(due.Timezone, "UTC") {
+		return nil
+	}
+
+	location, err := time.LoadLocation(due.Timezone)
+	if err != nil {
+		return nil
+	}
+
+	return location
+}
+
+// Offset changes are listed for this many years after the last recurring due date.
+const icsZoneRecurringYears = 5
+
+type icsZone struct {
+	location *time.Location
+	from     time.Time
+	to       time.Time
+}
+
+// collectICSZones returns the time zones of the due times, in order of appearance,
+// with the span of time their offset changes have to cover.
+func collectICSZones(tasks []Task) []*icsZone {
+	zones := make([]*icsZone, 0)
+	byName := make(map[string]*icsZone)
+
+	for _, task := range tasks {
+		location := icsLocation(task.Due)
+		if location == nil || task.Due.Datetime == "" {
+			continue
+		}
+
+		datetime, err := time.Parse(time.RFC3339, task.Due.Datetime)
+		if err != nil {
+			continue
+		}
+
+		from := time.Date(datetime.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(datetime.Year()+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+		if task.Due.Recurring {
+			to = to.AddDate(icsZoneRecurringYears, 0, 0)
+		}
+
+		zone, ok := byName[location.String()]
+		if !ok {
+			zone = &icsZone{location: location, from: from, to: to}
+			byName[location.String()] = zone
+			zones = append(zones, zone)
+			continue
+		}
+
+		if from.Before(zone.from) {
+			zone.from = from
+		}
+		if to.After(zone.to) {
+			zone.to = to
+		}
+	}
+
+	return zones
+}
+
+// writeICSZone writes the offset in use at the start of the span and every
+// change within it. Changes to a larger offset are written as daylight time.
+func writeICSZone(writer *icsWriter, zone *icsZone) {
+	writer.line("BEGIN", "VTIMEZONE")
+	writer.line("TZID", zone.location.String())
+
+	start := zone.from.In(zone.location)
+	name, offset := start.Zone()
+	writeICSObservance(writer, "STANDARD", start, offset, offset, name)
+
+	for day := zone.from; day.Before(zone.to); day = day.Add(24 * time.Hour) {
+		_, before := day.In(zone.location).Zone()
+		next := day.Add(24 * time.Hour)
+		if _, after := next.In(zone.location).Zone(); after == before {
+			continue
+		}
+
+		// The change happens within the day, find its second.
+		low, high := day, next
+		for high.Sub(low) > time.Second {
+			middle := low.Add(high.Sub(low) / 2)
+			if _, offset := middle.In(zone.location).Zone(); offset == before {
+				low = middle
+			} else {
+				high = middle
+			}
+		}
+
+		name, after := high.In(zone.location).Zone()
+		kind := "STANDARD"
+		if after > before {
+			kind = "DAYLIGHT"
+		}
+
+		// DTSTART is the local time just before the change.
+		writeICSObservance(writer, kind, high.In(time.FixedZone("", before)), before, after, name)
+	}
+
+	writer.line("END", "VTIMEZONE")
+}
+
+func writeICSObservance(writer *icsWriter, kind string, start time.Time, offsetFrom int, offsetTo int, name string) {
+	writer.line("BEGIN", kind)
+	writer.line("DTSTART", start.Format(icsDatetimeLayout))
+	writer.line("TZOFFSETFROM", formatICSOffset(offsetFrom))
+	writer.line("TZOFFSETTO", formatICSOffset(offsetTo))
+	if name != "" {
+		writer.line("TZNAME", escapeICSText(name))
+	}
+	writer.line("END", kind)
+}
+
+func formatICSOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+
+	value := fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset/60%60)
+	if offset%60 != 0 {
+		value += fmt.Sprintf("%02d", offset%60)
+	}
+
+	return value
+}
+
+func ICSUid(taskId int) string {
+	return "task-" + strconv.Itoa(taskId) + "@todoist.com"
+}
+
+// Todoist priorities go from 1 (normal) to 4 (urgent), iCalendar ones from 1 (highest) to 9 (lowest).
+func icsPriority(priority int) int {
+	switch priority {
+	case 4:
+		return 1
+	case 3:
+		return 5
+	case 2:
+		return 9
+	default:
+		return 0
+	}
+}
+
+func escapeICSText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
+
+type icsWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+// Content lines are folded at 75 octets without splitting multi-byte characters.
+func (w *icsWriter) line(name string, value string) {
+	if w.err != nil {
+		return
+	}
+
+	line := name + ":" + value
+	limit := icsLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		if _, w.err = w.w.WriteString(line[:cut] + "\r\n "); w.err != nil {
+			return
+		}
+		line = line[cut:]
+		limit = icsLineLimit - 1
+	}
+
+	_, w.err = w.w.WriteString(line + "\r\n")
+}
+
+// endregion
+
+// region DueStringToRRule
+
+var icsWeekdays = map[string]string{
+	"mon": "MO", "monday": "MO", "mondays": "MO",
+	"tue": "TU", "tues": "TU", "tuesday": "TU", "tuesdays": "TU",
+	"wed": "WE", "wednesday": "WE", "wednesdays": "WE",
+	"thu": "TH", "thur": "TH", "thurs": "TH", "thursday": "TH", "thursdays": "TH",
+	"fri": "FR", "friday": "FR", "fridays": "FR",
+	"sat": "SA", "saturday": "SA", "saturdays": "SA",
+	"sun": "SU", "sunday": "SU", "sundays": "SU",
+}
+
+var icsFrequencies = map[string]string{
+	"day": "DAILY", "days": "DAILY",
+	"week": "WEEKLY", "weeks": "WEEKLY",
+	"month": "MONTHLY", "months": "MONTHLY",
+	"year": "YEARLY", "years": "YEARLY",
+}
+
+// DueStringToRRule translates the simple English recurring due strings, such as
+// "every day", "every 2 weeks", "every other month" or "every mon, fri at 9am".
+// An empty string is returned for anything it does not understand.
+func DueStringToRRule(dueString string) string {
+	text := strings.ToLower(strings.TrimSpace(dueString))
+	text = strings.Replace(text, "every!", "every", 1)
+
+	for _, cut := range []string{" at ", " starting ", " from ", " until ", " ending ", " for "} {
+		if i := strings.Index(text, cut); i != -1 {
+			text = text[:i]
+		}
+	}
+
+	switch text {
+	case "daily":
+		return "FREQ=DAILY"
+	case "weekly":
+		return "FREQ=WEEKLY"
+	case "monthly":
+		return "FREQ=MONTHLY"
+	case "yearly", "annually":
+		return "FREQ=YEARLY"
+	}
+
+	if !strings.HasPrefix(text, "every ") {
+		return ""
+	}
+
+	words := strings.FieldsFunc(strings.TrimPrefix(text, "every "), func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+	if len(words) == 0 {
+		return ""
+	}
+
+	switch words[0] {
+	case "weekday", "workday":
+		if len(words) == 1 {
+			return "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"
+		}
+		return ""
+	case "weekend":
+		if len(words) == 1 {
+			return "FREQ=WEEKLY;BYDAY=SA,SU"
+		}
+		return ""
+	}
+
+	interval := 1
+	if words[0] == "other" {
+		interval, words = 2, words[1:]
+	} else if n, err := strconv.Atoi(words[0]); err == nil && n > 0 {
+		interval, words = n, words[1:]
+	}
+
+	if len(words) == 1 {
+		if frequency, ok := icsFrequencies[words[0]]; ok {
+			if interval == 1 {
+				return "FREQ=" + frequency
+			}
+			return "FREQ=" + frequency + ";INTERVAL=" + strconv.Itoa(interval)
+		}
+	}
+
+	days := make([]string, 0, len(words))
+	for _, word := range words {
+		if word == "and" {
+			continue
+		}
+
+		day, ok := icsWeekdays[word]
+		if !ok {
+			return ""
+		}
+		days = append(days, day)
+	}
+
+	if len(days) == 0 {
+		return ""
+	}
+
+	rule := "FREQ=WEEKLY"
+	if interval != 1 {
+		rule += ";INTERVAL=" + strconv.Itoa(interval)
+	}
+
+	return rule + ";BYDAY=" + strings.Join(days, ",")
+}
+
+// endregion
+
+// region ExportICS
+
+func (t *Todoist) ExportICS(ctx context.Context, w io.Writer, params *GetTasksParams, opts *ICSOpts) (err error) {
+	var tasks []Task
+	if tasks, err = t.GetTasks(ctx, params); err != nil {
+		return
+	}
+
+	return WriteICS(w, tasks, opts)
+}
+
+// endregion
+
+// region ICSHandler
+
+// ICSHandler serves the tasks matching its filter as an iCalendar feed.
+type ICSHandler struct {
+	// AllowQuery lets the "filter" and "project_id" query parameters replace
+	// the filter, which gives anyone with the feed URL access to every task.
+	AllowQuery bool
+
+	todoist *Todoist
+	opts    *ICSOpts
+	filter  string
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func NewICSHandler(todoist *Todoist, filter string, opts *ICSOpts) *ICSHandler {
+	return &ICSHandler{
+		todoist: todoist,
+		opts:    opts,
+		filter:  filter,
+	}
+}
+
+func (h *ICSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	params := MakeGetTasksParams().WithFilter(h.filter).WithLang(query.Get("lang"))
+	if h.AllowQuery {
+		if filter := query.Get("filter"); filter != "" {
+			params.WithFilter(filter)
+		}
+		if projectId, err := strconv.Atoi(query.Get("project_id")); err == nil {
+			params.WithProjectId(projectId)
+		}
+	}
+
+	// Errors are only logged, since the feed is usually served to anonymous clients.
+	tasks, err := h.todoist.GetTasks(r.Context(), params)
+	if err != nil {
+		h.logError(r.Context(), "ics feed: get tasks", err)
+		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return
+	}
+
+	var body bytes.Buffer
+	if err = WriteICS(&body, tasks, h.opts); err != nil {
+		h.logError(r.Context(), "ics feed: write calendar", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ICSContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(body.Len()))
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	if _, err = body.WriteTo(w); err != nil {
+		h.logError(r.Context(), "ics feed: send calendar", err)
+	}
+}
+
+func (h *ICSHandler) logError(ctx context.Context, msg string, err error) {
+	if h.todoist.opts.Logger != nil {
+		h.todoist.opts.Logger.ErrorContext(ctx, msg, "error", h.todoist.redactToken(err.Error()))
+	}
+}
+
+// endregion
@@ -0,0 +1,523 @@
+package todoist
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ICSComponent struct {
+	Name       string
+	Properties []ICSProperty
+	Components []*ICSComponent
+}
+
+type ICSProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+type ICSItem struct {
+	Uid         string
+	Summary     string
+	Description string
+	Priority    int
+	Date        string
+	Datetime    string
+	DueString   string
+}
+
+type ICSUidStore interface {
+	Lookup(uid string) (taskId int, ok bool)
+	Store(uid string, taskId int)
+}
+
+type ICSUidMap map[string]int
+
+// ICSImportOpts configures ImportICS. Without a Store, the UID of every
+// imported item is kept in the last line of the task description, and the
+// tasks of the project are searched for it on the next import.
+type ICSImportOpts struct {
+	ProjectId int
+	SectionId int
+	Store     ICSUidStore
+	Location  *time.Location
+}
+
+type ICSImportResult struct {
+	Added   []int
+	Updated []int
+	Skipped []string
+}
+
+// region ParseICS
+
+func ParseICS(r io.Reader) (root *ICSComponent, err error) {
+	root = &ICSComponent{}
+	stack := []*ICSComponent{root}
+
+	var lines []string
+	if lines, err = unfoldICSLines(r); err != nil {
+		return
+	}
+
+	for number, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		var property ICSProperty
+		if property, err = parseICSProperty(line); err != nil {
+			return nil, fmt.Errorf("line %d: %w", number+1, err)
+		}
+
+		current := stack[len(stack)-1]
+		switch property.Name {
+		case "BEGIN":
+			component := &ICSComponent{Name: strings.ToUpper(property.Value)}
+			current.Components = append(current.Components, component)
+			stack = append(stack, component)
+		case "END":
+			if len(stack) == 1 || current.Name != strings.ToUpper(property.Value) {
+				return nil, fmt.Errorf("line %d: unexpected END:%s", number+1, property.Value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			current.Properties = append(current.Properties, property)
+		}
+	}
+
+	if len(stack) != 1 {
+		return nil, fmt.Errorf("unterminated component %s", stack[len(stack)-1].Name)
+	}
+
+	return
+}
+
+func (c *ICSComponent) Property(name string) *ICSProperty {
+	for i := range c.Properties {
+		if c.Properties[i].Name == name {
+			return &c.Properties[i]
+		}
+	}
+
+	return nil
+}
+
+func (c *ICSComponent) Walk(fn func(component *ICSComponent)) {
+	fn(c)
+	for _, component := range c.Components {
+		component.Walk(fn)
+	}
+}
+
+func unfoldICSLines(r io.Reader) (lines []string, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) != 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
+
+// Parameter values may be quoted and contain ";", ":" or "," characters.
+func parseICSProperty(line string) (property ICSProperty, err error) {
+	property.Params = make(map[string]string)
+
+	quoted := false
+	start := 0
+	var key string
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == ';' || c == ':':
+			token := line[start:i]
+			if property.Name == "" {
+				property.Name = strings.ToUpper(token)
+			} else if key != "" {
+				property.Params[key] = strings.Trim(token, `"`)
+				key = ""
+			}
+
+			if c == ':' {
+				property.Value = line[i+1:]
+				return
+			}
+			start = i + 1
+		case c == '=' && key == "" && property.Name != "":
+			key = strings.ToUpper(line[start:i])
+			start = i + 1
+		}
+	}
+
+	return property, errors.New("missing property value")
+}
+
+func unescapeICSText(text string) string {
+	return strings.NewReplacer(
+		`\\`, `\`,
+		`\;`, ";",
+		`\,`, ",",
+		`\n`, "\n",
+		`\N`, "\n",
+	).Replace(text)
+}
+
+// endregion
+
+// region ParseICSItems
+
+func ParseICSItems(r io.Reader, location *time.Location) (items []ICSItem, err error) {
+	var root *ICSComponent
+	if root, err = ParseICS(r); err != nil {
+		return
+	}
+
+	if location == nil {
+		location = time.UTC
+	}
+
+	items = make([]ICSItem, 0)
+	root.Walk(func(component *ICSComponent) {
+		if err != nil || (component.Name != ICSEvent && component.Name != ICSTodo) {
+			return
+		}
+
+		var item ICSItem
+		if item, err = makeICSItem(component, location); err != nil {
+			err = fmt.Errorf("%s %q: %w", component.Name, item.Uid, err)
+			return
+		}
+		items = append(items, item)
+	})
+
+	return
+}
+
+func makeICSItem(component *ICSComponent, location *time.Location) (item ICSItem, err error) {
+	if property := component.Property("UID"); property != nil {
+		item.Uid = property.Value
+	}
+
+	if property := component.Property("SUMMARY"); property != nil {
+		item.Summary = unescapeICSText(property.Value)
+	}
+
+	if property := component.Property("DESCRIPTION"); property != nil {
+		item.Description = unescapeICSText(property.Value)
+	}
+
+	if property := component.Property("PRIORITY"); property != nil {
+		if value, err := strconv.Atoi(property.Value); err == nil {
+			item.Priority = todoistPriority(value)
+		}
+	}
+
+	due := component.Property("DTSTART")
+	if component.Name == ICSTodo {
+		if property := component.Property("DUE"); property != nil {
+			due = property
+		}
+	}
+
+	if due == nil {
+		return
+	}
+
+	var value time.Time
+	var allDay bool
+	if value, allDay, err = parseICSTime(due, location); err != nil {
+		return
+	}
+
+	if allDay {
+		item.Date = value.Format("2006-01-02")
+	} else {
+		item.Datetime = value.UTC().Format(time.RFC3339)
+	}
+
+	if property := component.Property("RRULE"); property != nil {
+		item.DueString = RRuleToDueString(property.Value, value, allDay)
+	}
+
+	return
+}
+
+func parseICSTime(property *ICSProperty, location *time.Location) (value time.Time, allDay bool, err error) {
+	if property.Params["VALUE"] == "DATE" || len(property.Value) == len(icsDateLayout) {
+		value, err = time.Parse(icsDateLayout, property.Value)
+		return value, true, err
+	}
+
+	if strings.HasSuffix(property.Value, "Z") {
+		value, err = time.Parse(icsDatetimeLayout+"Z", property.Value)
+		return
+	}
+
+	if tzid := property.Params["TZID"]; tzid != "" {
+		if location, err = time.LoadLocation(tzid); err != nil {
+			return
+		}
+	}
+
+	value, err = time.ParseInLocation(icsDatetimeLayout, property.Value, location)
+
+	return
+}
+
+// Inverse of icsPriority: 1-4 are high, 5 is medium and 6-9 are low priorities.
+func todoistPriority(priority int) int {
+	switch {
+	case priority >= 1 && priority <= 4:
+		return 4
+	case priority == 5:
+		return 3
+	case priority >= 6 && priority <= 9:
+		return 2
+	default:
+		return 1
+	}
+}
+
+var rruleFrequencies = map[string]string{
+	"DAILY":   "day",
+	"WEEKLY":  "week",
+	"MONTHLY": "month",
+	"YEARLY":  "year",
+}
+
+var rruleWeekdays = map[string]string{
+	"MO": "mon", "TU": "tue", "WE": "wed", "TH": "thu", "FR": "fri", "SA": "sat", "SU": "sun",
+}
+
+// RRuleToDueString handles FREQ, INTERVAL, plain weekly BYDAY and UNTIL.
+// An empty string is returned for anything else, so the item gets a single due date.
+func RRuleToDueString(rule string, start time.Time, allDay bool) string {
+	parts := make(map[string]string)
+	for _, part := range strings.Split(rule, ";") {
+		if i := strings.IndexByte(part, '='); i != -1 {
+			parts[strings.ToUpper(part[:i])] = strings.ToUpper(part[i+1:])
+		}
+	}
+
+	unit, ok := rruleFrequencies[parts["FREQ"]]
+	if !ok {
+		return ""
+	}
+
+	for key := range parts {
+		switch key {
+		case "FREQ", "INTERVAL", "BYDAY", "UNTIL", "WKST":
+		default:
+			return ""
+		}
+	}
+
+	interval := 1
+	if value, ok := parts["INTERVAL"]; ok {
+		var err error
+		if interval, err = strconv.Atoi(value); err != nil || interval < 1 {
+			return ""
+		}
+	}
+
+	dueString := strings.Builder{}
+	dueString.WriteString("every ")
+	if byDay, ok := parts["BYDAY"]; ok {
+		if unit != "week" || interval != 1 {
+			return ""
+		}
+
+		days := strings.Split(byDay, ",")
+		for i, day := range days {
+			name, ok := rruleWeekdays[day]
+			if !ok {
+				return ""
+			}
+			days[i] = name
+		}
+		dueString.WriteString(strings.Join(days, ", "))
+	} else if interval == 1 {
+		dueString.WriteString(unit)
+	} else {
+		dueString.WriteString(strconv.Itoa(interval) + " " + unit + "s")
+	}
+
+	if !allDay {
+		dueString.WriteString(" at " + start.Format("15:04"))
+	}
+
+	dueString.WriteString(" starting " + start.Format("2006-01-02"))
+
+	if until, ok := parts["UNTIL"]; ok && len(until) >= len(icsDateLayout) {
+		if value, err := time.Parse(icsDateLayout, until[:len(icsDateLayout)]); err == nil {
+			dueString.WriteString(" until " + value.Format("2006-01-02"))
+		}
+	}
+
+	return dueString.String()
+}
+
+// endregion
+
+// region ICSUidMap
+
+func (m ICSUidMap) Lookup(uid string) (taskId int, ok bool) {
+	taskId, ok = m[uid]
+	return
+}
+
+func (m ICSUidMap) Store(uid string, taskId int) {
+	m[uid] = taskId
+}
+
+// endregion
+
+// region ImportICS
+
+func (t *Todoist) ImportICS(ctx context.Context, r io.Reader, opts *ICSImportOpts) (result *ICSImportResult, err error) {
+	if opts == nil {
+		opts = new(ICSImportOpts)
+	}
+
+	var items []ICSItem
+	if items, err = ParseICSItems(r, opts.Location); err != nil {
+		return
+	}
+
+	store := opts.Store
+	if store == nil {
+		if store, err = t.icsUidMarkers(ctx, opts.ProjectId); err != nil {
+			return
+		}
+	}
+	description := func(item ICSItem) string {
+		if opts.Store != nil || item.Uid == "" {
+			return item.Description
+		}
+
+		return icsUidDescription(item.Description, item.Uid)
+	}
+
+	result = &ICSImportResult{
+		Added:   make([]int, 0),
+		Updated: make([]int, 0),
+		Skipped: make([]string, 0),
+	}
+
+	for _, item := range items {
+		if item.Summary == "" {
+			result.Skipped = append(result.Skipped, item.Uid)
+			continue
+		}
+
+		if taskId, ok := store.Lookup(item.Uid); ok && item.Uid != "" {
+			params := MakeUpdateTaskParams().
+				WithContent(item.Summary).
+				WithDescription(description(item)).
+				WithPriority(item.Priority)
+
+			switch {
+			case item.DueString != "":
+				params.WithDueString(item.DueString)
+			case item.Datetime != "":
+				params.WithDueDatetime(item.Datetime)
+			case item.Date != "":
+				params.WithDueDate(item.Date)
+			}
+
+			if err = t.UpdateTask(ctx, taskId, params); err == nil {
+				result.Updated = append(result.Updated, taskId)
+				continue
+			}
+
+			// The task was deleted since the previous import, so it is created again.
+			if !IsNotFound(err) {
+				return result, fmt.Errorf("update %q: %w", item.Uid, err)
+			}
+		}
+
+		params := MakeAddTaskParams().
+			WithContent(item.Summary).
+			WithDescription(description(item)).
+			WithProjectId(opts.ProjectId).
+			WithSectionId(opts.SectionId).
+			WithPriority(item.Priority)
+
+		switch {
+		case item.DueString != "":
+			params.WithDueString(item.DueString)
+		case item.Datetime != "":
+			params.WithDueDatetime(item.Datetime)
+		case item.Date != "":
+			params.WithDueDate(item.Date)
+		}
+
+		var task *Task
+		if task, err = t.AddTask(ctx, params); err != nil {
+			return result, fmt.Errorf("add %q: %w", item.Uid, err)
+		}
+
+		if item.Uid != "" {
+			store.Store(item.Uid, task.Id)
+		}
+		result.Added = append(result.Added, task.Id)
+	}
+
+	return result, nil
+}
+
+const icsUidMarker = "ics-uid: "
+
+// icsUidMarkers reads the UIDs from the descriptions of the tasks in the
+// project, or in every project when projectId is 0.
+func (t *Todoist) icsUidMarkers(ctx context.Context, projectId int) (store ICSUidMap, err error) {
+	var tasks []Task
+	if tasks, err = t.GetTasks(ctx, MakeGetTasksParams().WithProjectId(projectId)); err != nil {
+		return
+	}
+
+	store = make(ICSUidMap)
+	for _, task := range tasks {
+		if uid := icsUidFromDescription(task.Description); uid != "" {
+			store.Store(uid, task.Id)
+		}
+	}
+
+	return
+}
+
+func icsUidDescription(description string, uid string) string {
+	if description == "" {
+		return icsUidMarker + uid
+	}
+
+	return description + "\n\n" + icsUidMarker + uid
+}
+
+func icsUidFromDescription(description string) string {
+	lines := strings.Split(description, "\n")
+	if last := lines[len(lines)-1]; strings.HasPrefix(last, icsUidMarker) {
+		return strings.TrimPrefix(last, icsUidMarker)
+	}
+
+	return ""
+}
+
+// endregion
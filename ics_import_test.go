@@ -0,0 +1,121 @@
+package todoist
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+const icsImportFeed = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:standup@example.com\r\n" +
+	"SUMMARY:Standup\r\n" +
+	"DESCRIPTION:Daily sync\r\n" +
+	"DTSTART;TZID=Europe/Berlin:20240506T093000\r\n" +
+	"RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VTODO\r\n" +
+	"UID:taxes@example.com\r\n" +
+	"SUMMARY:File taxes\r\n" +
+	"PRIORITY:1\r\n" +
+	"DUE;VALUE=DATE:20240531\r\n" +
+	"END:VTODO\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICSItems(t *testing.T) {
+	items, err := ParseICSItems(strings.NewReader(icsImportFeed), time.UTC)
+	if err != nil {
+		t.Fatalf("ParseICSItems: %s", err)
+	}
+
+	want := []ICSItem{
+		{Uid: "standup@example.com", Summary: "Standup", Description: "Daily sync", Datetime: "2024-05-06T07:30:00Z", DueString: "every mon, wed, fri at 09:30 starting 2024-05-06"},
+		{Uid: "taxes@example.com", Summary: "File taxes", Priority: 4, Date: "2024-05-31"},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("got %d items, want %d", len(items), len(want))
+	}
+	for i := range want {
+		if items[i] != want[i] {
+			t.Errorf("item %d: got %+v, want %+v", i, items[i], want[i])
+		}
+	}
+}
+
+func TestRRuleToDueString(t *testing.T) {
+	start := time.Date(2024, 5, 6, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		rule   string
+		allDay bool
+		want   string
+	}{
+		{"FREQ=DAILY", true, "every day starting 2024-05-06"},
+		{"FREQ=WEEKLY;INTERVAL=2", false, "every 2 weeks at 09:30 starting 2024-05-06"},
+		{"FREQ=WEEKLY;BYDAY=TU,TH", true, "every tue, thu starting 2024-05-06"},
+		{"FREQ=MONTHLY;UNTIL=20241231T000000Z", true, "every month starting 2024-05-06 until 2024-12-31"},
+		{"FREQ=MONTHLY;BYMONTHDAY=15", true, ""},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO", true, ""},
+		{"FREQ=SECONDLY", true, ""},
+	}
+
+	for _, test := range tests {
+		if got := RRuleToDueString(test.rule, start, test.allDay); got != test.want {
+			t.Errorf("RRuleToDueString(%q) = %q, want %q", test.rule, got, test.want)
+		}
+	}
+}
+
+func TestImportICSWithoutStoreIsIdempotent(t *testing.T) {
+	stub := newAPIStub(t)
+	client := stub.client()
+	ctx := context.Background()
+
+	result, err := client.ImportICS(ctx, strings.NewReader(icsImportFeed), &ICSImportOpts{ProjectId: 7, Location: time.UTC})
+	if err != nil {
+		t.Fatalf("first import: %s", err)
+	}
+	if len(result.Added) != 2 || len(result.Updated) != 0 {
+		t.Fatalf("first import: got %+v, want 2 added", result)
+	}
+
+	result, err = client.ImportICS(ctx, strings.NewReader(icsImportFeed), &ICSImportOpts{ProjectId: 7, Location: time.UTC})
+	if err != nil {
+		t.Fatalf("second import: %s", err)
+	}
+	if len(result.Added) != 0 || len(result.Updated) != 2 {
+		t.Errorf("second import: got %+v, want 2 updated", result)
+	}
+
+	tasks := stub.objects(TasksEndpoint)
+	if len(tasks) != 2 {
+		t.Fatalf("got %d tasks, want 2", len(tasks))
+	}
+	if got, want := tasks[0]["description"], "Daily sync\n\nics-uid: standup@example.com"; got != want {
+		t.Errorf("description: got %q, want %q", got, want)
+	}
+}
+
+func TestImportICSWithStore(t *testing.T) {
+	stub := newAPIStub(t)
+	client := stub.client()
+	store := make(ICSUidMap)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.ImportICS(context.Background(), strings.NewReader(icsImportFeed), &ICSImportOpts{Store: store, Location: time.UTC}); err != nil {
+			t.Fatalf("import %d: %s", i, err)
+		}
+	}
+
+	tasks := stub.objects(TasksEndpoint)
+	if len(tasks) != 2 {
+		t.Fatalf("got %d tasks, want 2", len(tasks))
+	}
+	if got := tasks[1]["description"]; got != nil && got != "" {
+		t.Errorf("description: got %q, want no UID marker", got)
+	}
+	if taskId, ok := store.Lookup("taxes@example.com"); !ok || taskId != tasks[1]["id"] {
+		t.Errorf("store: got %d, want %v", taskId, tasks[1]["id"])
+	}
+}
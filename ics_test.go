@@ -0,0 +1,206 @@
+package todoist
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteICS(t *testing.T) {
+	tasks := []Task{
+		{Id: 1, Content: "Standup", Priority: 4, Due: Due{String: "every mon, wed", Recurring: true, Datetime: "2024-05-06T07:30:00Z", Timezone: "Europe/Berlin"}},
+		{Id: 2, Content: "File taxes, finally", Due: Due{Date: "2024-05-31"}},
+		{Id: 3, Content: "Call", Due: Due{Datetime: "2024-05-07T15:00:00Z", Timezone: "UTC"}},
+		{Id: 4, Content: "No due date"},
+	}
+
+	tests := []struct {
+		component string
+		want      []string
+		missing   []string
+	}{
+		{
+			component: ICSTodo,
+			want: []string{
+				"BEGIN:VTIMEZONE\r\nTZID:Europe/Berlin\r\n",
+				"DUE;TZID=Europe/Berlin:20240506T093000\r\nDTSTART;TZID=Europe/Berlin:20240506T093000\r\nRRULE:FREQ=WEEKLY;BYDAY=MO,WE\r\n",
+				"PRIORITY:1\r\n",
+				"SUMMARY:File taxes\\, finally\r\n",
+				"DUE;VALUE=DATE:20240531\r\nSTATUS:NEEDS-ACTION\r\n",
+				"DUE:20240507T150000Z\r\n",
+			},
+			missing: []string{"No due date", "TZID:UTC"},
+		},
+		{
+			component: ICSEvent,
+			want: []string{
+				"DTSTART;TZID=Europe/Berlin:20240506T093000\r\nDTEND;TZID=Europe/Berlin:20240506T103000\r\n",
+				"DTSTART;VALUE=DATE:20240531\r\nDTEND;VALUE=DATE:20240601\r\n",
+			},
+			missing: []string{"DUE", "STATUS"},
+		},
+	}
+
+	for _, test := range tests {
+		var buffer bytes.Buffer
+		if err := WriteICS(&buffer, tasks, &ICSOpts{Component: test.component}); err != nil {
+			t.Fatalf("%s: WriteICS: %s", test.component, err)
+		}
+
+		for _, want := range test.want {
+			if !strings.Contains(buffer.String(), want) {
+				t.Errorf("%s: missing %q in\n%s", test.component, want, buffer.String())
+			}
+		}
+		for _, missing := range test.missing {
+			if strings.Contains(buffer.String(), missing) {
+				t.Errorf("%s: unexpected %q in\n%s", test.component, missing, buffer.String())
+			}
+		}
+	}
+}
+
+func TestWriteICSTimeZone(t *testing.T) {
+	tasks := []Task{{Id: 1, Content: "Standup", Due: Due{Datetime: "2024-05-06T07:30:00Z", Timezone: "Europe/Berlin"}}}
+
+	var buffer bytes.Buffer
+	if err := WriteICS(&buffer, tasks, nil); err != nil {
+		t.Fatalf("WriteICS: %s", err)
+	}
+
+	want := "BEGIN:VTIMEZONE\r\n" +
+		"TZID:Europe/Berlin\r\n" +
+		"BEGIN:STANDARD\r\nDTSTART:20240101T010000\r\nTZOFFSETFROM:+0100\r\nTZOFFSETTO:+0100\r\nTZNAME:CET\r\nEND:STANDARD\r\n" +
+		"BEGIN:DAYLIGHT\r\nDTSTART:20240331T020000\r\nTZOFFSETFROM:+0100\r\nTZOFFSETTO:+0200\r\nTZNAME:CEST\r\nEND:DAYLIGHT\r\n" +
+		"BEGIN:STANDARD\r\nDTSTART:20241027T030000\r\nTZOFFSETFROM:+0200\r\nTZOFFSETTO:+0100\r\nTZNAME:CET\r\nEND:STANDARD\r\n" +
+		"END:VTIMEZONE\r\n"
+	if !strings.Contains(buffer.String(), want) {
+		t.Errorf("missing time zone in\n%s", buffer.String())
+	}
+}
+
+func TestWriteICSRoundTrip(t *testing.T) {
+	tasks := []Task{
+		{Id: 1, Content: "Standup; daily", Description: "Room 4,\nsecond floor", Priority: 3, Due: Due{String: "every weekday", Recurring: true, Datetime: "2024-05-06T07:30:00Z", Timezone: "Europe/Berlin"}},
+		{Id: 2, Content: strings.Repeat("Долгая задача ", 10), Due: Due{Date: "2024-05-31"}},
+	}
+
+	var buffer bytes.Buffer
+	if err := WriteICS(&buffer, tasks, nil); err != nil {
+		t.Fatalf("WriteICS: %s", err)
+	}
+
+	items, err := ParseICSItems(&buffer, time.UTC)
+	if err != nil {
+		t.Fatalf("ParseICSItems: %s", err)
+	}
+
+	want := []ICSItem{
+		{Uid: ICSUid(1), Summary: "Standup; daily", Description: "Room 4,\nsecond floor", Priority: 3, Datetime: "2024-05-06T07:30:00Z", DueString: "every mon, tue, wed, thu, fri at 09:30 starting 2024-05-06"},
+		{Uid: ICSUid(2), Summary: tasks[1].Content, Date: "2024-05-31"},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("got %d items, want %d", len(items), len(want))
+	}
+	for i := range want {
+		if items[i] != want[i] {
+			t.Errorf("item %d: got %+v, want %+v", i, items[i], want[i])
+		}
+	}
+}
+
+func TestDueStringToRRule(t *testing.T) {
+	tests := []struct {
+		dueString string
+		want      string
+	}{
+		{"every day", "FREQ=DAILY"},
+		{"Every! 2 weeks", "FREQ=WEEKLY;INTERVAL=2"},
+		{"every other month", "FREQ=MONTHLY;INTERVAL=2"},
+		{"every mon, fri at 9am", "FREQ=WEEKLY;BYDAY=MO,FR"},
+		{"every weekday", "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"},
+		{"every weekend", "FREQ=WEEKLY;BYDAY=SA,SU"},
+		{"yearly", "FREQ=YEARLY"},
+		{"every 3rd friday", ""},
+		{"tomorrow", ""},
+	}
+
+	for _, test := range tests {
+		if got := DueStringToRRule(test.dueString); got != test.want {
+			t.Errorf("DueStringToRRule(%q) = %q, want %q", test.dueString, got, test.want)
+		}
+	}
+}
+
+func TestICSHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		allowQuery bool
+		query      string
+		want       string
+	}{
+		{"configured filter", false, "", "today"},
+		{"query ignored", false, "?filter=all", "today"},
+		{"query allowed", true, "?filter=all", "all"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := ""
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				filter = r.URL.Query().Get("filter")
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`[{"id": 1, "content": "Task", "due": {"date": "2024-05-31"}}]`))
+			}))
+			defer server.Close()
+
+			client := New(&Opts{Token: "secret", Client: &http.Client{Transport: rewriteHost(http.DefaultTransport, server.URL)}})
+			handler := NewICSHandler(client, "today", nil)
+			handler.AllowQuery = test.allowQuery
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/feed.ics"+test.query, nil))
+
+			if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), "SUMMARY:Task") {
+				t.Errorf("got %d %q", recorder.Code, recorder.Body.String())
+			}
+			if filter != test.want {
+				t.Errorf("got filter %q, want %q", filter, test.want)
+			}
+		})
+	}
+}
+
+func TestICSHandlerHidesErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "secret upstream details", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := New(&Opts{Token: "secret", Client: &http.Client{Transport: rewriteHost(http.DefaultTransport, server.URL)}})
+	recorder := httptest.NewRecorder()
+	NewICSHandler(client, "today", nil).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/feed.ics", nil))
+
+	if recorder.Code != http.StatusBadGateway || strings.Contains(recorder.Body.String(), "403") {
+		t.Errorf("got %d %q, want a generic 502", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestExportICS(t *testing.T) {
+	stub := newAPIStub(t)
+	stub.add(TasksEndpoint, map[string]interface{}{"content": "Task", "project_id": 5, "due": map[string]interface{}{"date": "2024-05-31"}})
+	stub.add(TasksEndpoint, map[string]interface{}{"content": "Other", "project_id": 6, "due": map[string]interface{}{"date": "2024-05-31"}})
+
+	var buffer bytes.Buffer
+	if err := stub.client().ExportICS(context.Background(), &buffer, MakeGetTasksParams().WithProjectId(5), nil); err != nil {
+		t.Fatalf("ExportICS: %s", err)
+	}
+
+	if !strings.Contains(buffer.String(), "SUMMARY:Task\r\n") || strings.Contains(buffer.String(), "Other") {
+		t.Errorf("got\n%s", buffer.String())
+	}
+}
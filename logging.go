@@ -0,0 +1,206 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const DefaultLogBodyLimit = 4096
+
+const RequestIdHeader = "X-Request-Id"
+
+const redacted = "[REDACTED]"
+
+// Fields that are redacted from logged bodies whether or not RedactComments is set.
+var secretFields = map[string]bool{
+	"password":         true,
+	"current_password": true,
+	"token":            true,
+}
+
+// Logger is satisfied by *slog.Logger. Arguments are alternating keys and values.
+type Logger interface {
+	DebugContext(ctx context.Context, msg string, args ...interface{})
+	InfoContext(ctx context.Context, msg string, args ...interface{})
+	WarnContext(ctx context.Context, msg string, args ...interface{})
+	ErrorContext(ctx context.Context, msg string, args ...interface{})
+}
+
+// logAttempt logs a single HTTP round trip. The token is never logged: headers
+// are left out and any occurrence of the token in bodies or errors is replaced.
+func (t *Todoist) logAttempt(ctx context.Context, req *Request, attempt int, status int, latency time.Duration, body []byte, err error) {
+	if t.opts.Logger == nil {
+		return
+	}
+
+	args := []interface{}{
+		"method", req.Method,
+		"endpoint", req.Endpoint,
+		"params", req.Params,
+		"status", status,
+		"latency", latency,
+		"retry", attempt,
+		"request_id", req.Header.Get(RequestIdHeader),
+	}
+
+	if t.opts.LogBodies {
+		args = append(args, "request_body", t.logBody(req.Endpoint, req.Payload), "response_body", t.logBody(req.Endpoint, body))
+	}
+
+	switch {
+	case err != nil && status == 0:
+		t.opts.Logger.ErrorContext(ctx, "todoist request failed", append(args, "error", t.redactToken(err.Error()))...)
+	case status == http.StatusTooManyRequests:
+		t.opts.Logger.WarnContext(ctx, "todoist request rate limited", args...)
+	case status >= http.StatusBadRequest:
+		t.opts.Logger.ErrorContext(ctx, "todoist request failed", args...)
+	default:
+		t.opts.Logger.DebugContext(ctx, "todoist request", args...)
+	}
+}
+
+func (t *Todoist) logBody(endpoint string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	body = redactSecrets(body)
+	if t.opts.RedactComments {
+		body = redactComments(endpoint, body)
+	}
+
+	text := t.redactToken(string(body))
+
+	limit := t.opts.LogBodyLimit
+	if limit <= 0 {
+		limit = DefaultLogBodyLimit
+	}
+	if len(text) > limit {
+		for limit > 0 && !utf8.RuneStart(text[limit]) {
+			limit--
+		}
+		text = text[:limit] + "..."
+	}
+
+	return text
+}
+
+func (t *Todoist) redactToken(text string) string {
+	if t.opts.Token == "" {
+		return text
+	}
+
+	return strings.ReplaceAll(text, t.opts.Token, redacted)
+}
+
+// redactSecrets hides passwords and tokens, such as the current password sent
+// to change the email or the API token returned with the user.
+func redactSecrets(body []byte) []byte {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil || !redactFields(value, secretFields) {
+		return body
+	}
+
+	if redactedBody, err := json.Marshal(value); err == nil {
+		return redactedBody
+	}
+
+	return []byte(redacted)
+}
+
+func redactFields(value interface{}, fields map[string]bool) (found bool) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if fields[key] {
+				value[key] = redacted
+				found = true
+			} else if redactFields(field, fields) {
+				found = true
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			if redactFields(item, fields) {
+				found = true
+			}
+		}
+	}
+
+	return
+}
+
+// redactComments hides the content of comments returned by the comments endpoints,
+// notes returned by the Sync API, the arguments of note commands and the extra
+// data of note activity events.
+func redactComments(endpoint string, body []byte) []byte {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		if strings.HasPrefix(endpoint, "comments") {
+			return []byte(redacted)
+		}
+
+		return body
+	}
+
+	if strings.HasPrefix(endpoint, "comments") {
+		value = redactContent(value)
+	} else {
+		value = redactNotes(value)
+	}
+
+	if redactedBody, err := json.Marshal(value); err == nil {
+		return redactedBody
+	}
+
+	return []byte(redacted)
+}
+
+func redactContent(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if key == "content" {
+				value[key] = redacted
+			} else {
+				value[key] = redactContent(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactContent(item)
+		}
+	}
+
+	return value
+}
+
+func redactNotes(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if commandType, ok := value["type"].(string); ok && strings.HasPrefix(commandType, "note_") {
+			value["args"] = redactContent(value["args"])
+		}
+		if extraData, ok := value["extra_data"]; ok && value["object_type"] == string(ActivityNote) {
+			value["extra_data"] = redactContent(extraData)
+		}
+
+		for key, field := range value {
+			if key == "notes" || key == "project_notes" {
+				value[key] = redactContent(field)
+			} else {
+				value[key] = redactNotes(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactNotes(item)
+		}
+	}
+
+	return value
+}
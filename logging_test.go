@@ -0,0 +1,204 @@
+package todoist
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+)
+
+type logEntry struct {
+	level string
+	msg   string
+	args  map[string]interface{}
+}
+
+type recordingLogger struct {
+	mutex   sync.Mutex
+	entries []logEntry
+}
+
+func (l *recordingLogger) log(level string, msg string, args []interface{}) {
+	entry := logEntry{level: level, msg: msg, args: make(map[string]interface{})}
+	for i := 0; i+1 < len(args); i += 2 {
+		entry.args[args[i].(string)] = args[i+1]
+	}
+
+	l.mutex.Lock()
+	l.entries = append(l.entries, entry)
+	l.mutex.Unlock()
+}
+
+func (l *recordingLogger) DebugContext(_ context.Context, msg string, args ...interface{}) {
+	l.log("debug", msg, args)
+}
+
+func (l *recordingLogger) InfoContext(_ context.Context, msg string, args ...interface{}) {
+	l.log("info", msg, args)
+}
+
+func (l *recordingLogger) WarnContext(_ context.Context, msg string, args ...interface{}) {
+	l.log("warn", msg, args)
+}
+
+func (l *recordingLogger) ErrorContext(_ context.Context, msg string, args ...interface{}) {
+	l.log("error", msg, args)
+}
+
+func TestLogBody(t *testing.T) {
+	tests := []struct {
+		name           string
+		endpoint       string
+		body           string
+		redactComments bool
+		want           string
+	}{
+		{
+			name:     "password",
+			endpoint: "sync",
+			body:     `{"commands":[{"type":"user_update","args":{"current_password":"hunter2","email":"a@example.com"}}]}`,
+			want:     `{"commands":[{"args":{"current_password":"[REDACTED]","email":"a@example.com"},"type":"user_update"}]}`,
+		},
+		{
+			name:     "user token",
+			endpoint: "sync",
+			body:     `{"user":{"token":"other","full_name":"Ann"}}`,
+			want:     `{"user":{"full_name":"Ann","token":"[REDACTED]"}}`,
+		},
+		{
+			name:     "client token",
+			endpoint: "tasks",
+			body:     "not json with secret-token inside",
+			want:     "not json with [REDACTED] inside",
+		},
+		{
+			name:     "comments kept",
+			endpoint: "comments",
+			body:     `[{"id":1,"content":"private"}]`,
+			want:     `[{"id":1,"content":"private"}]`,
+		},
+		{
+			name:           "comments",
+			endpoint:       "comments/1",
+			body:           `{"id":1,"content":"private"}`,
+			redactComments: true,
+			want:           `{"content":"[REDACTED]","id":1}`,
+		},
+		{
+			name:           "invalid comments",
+			endpoint:       "comments",
+			body:           `private`,
+			redactComments: true,
+			want:           `[REDACTED]`,
+		},
+		{
+			name:           "sync notes",
+			endpoint:       "sync",
+			body:           `{"items":[{"content":"task"}],"notes":[{"content":"private"}]}`,
+			redactComments: true,
+			want:           `{"items":[{"content":"task"}],"notes":[{"content":"[REDACTED]"}]}`,
+		},
+		{
+			name:           "note commands",
+			endpoint:       "sync",
+			body:           `{"commands":[{"type":"note_add","args":{"content":"private"}},{"type":"item_add","args":{"content":"task"}}]}`,
+			redactComments: true,
+			want:           `{"commands":[{"args":{"content":"[REDACTED]"},"type":"note_add"},{"args":{"content":"task"},"type":"item_add"}]}`,
+		},
+		{
+			name:           "note activity",
+			endpoint:       "activity/get",
+			body:           `{"events":[{"object_type":"note","extra_data":{"content":"private"}}]}`,
+			redactComments: true,
+			want:           `{"events":[{"extra_data":{"content":"[REDACTED]"},"object_type":"note"}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := New(&Opts{Token: "secret-token", RedactComments: test.redactComments})
+			if got := client.logBody(test.endpoint, []byte(test.body)); got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLogBodyLimit(t *testing.T) {
+	tests := []struct {
+		limit int
+		body  string
+		want  string
+	}{
+		{10, "short", "short"},
+		{5, "abcdefgh", "abcde..."},
+		{5, "abcdéfgh", "abcd..."},
+		{4, "日本語", "日..."},
+		{1, "日本語", "..."},
+	}
+
+	for _, test := range tests {
+		client := New(&Opts{LogBodyLimit: test.limit})
+		got := client.logBody("tasks", []byte(test.body))
+		if got != test.want {
+			t.Errorf("%q limited to %d: got %q, want %q", test.body, test.limit, got, test.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("%q limited to %d: got invalid UTF-8", test.body, test.limit)
+		}
+	}
+}
+
+func TestLogAttempt(t *testing.T) {
+	tests := []struct {
+		status    int
+		wantLevel string
+	}{
+		{http.StatusOK, "debug"},
+		{http.StatusTooManyRequests, "warn"},
+		{http.StatusForbidden, "error"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(test.status)
+				_, _ = w.Write([]byte(`[{"id":1,"name":"Inbox","token":"secret-token"}]`))
+			}))
+			defer server.Close()
+
+			logger := &recordingLogger{}
+			client := New(&Opts{
+				Token:      "secret-token",
+				Client:     &http.Client{Transport: rewriteHost(http.DefaultTransport, server.URL)},
+				MaxRetries: -1,
+				Logger:     logger,
+				LogBodies:  true,
+			})
+			_, _ = client.GetProjects(context.Background())
+
+			if len(logger.entries) != 1 {
+				t.Fatalf("got %d log entries, want 1", len(logger.entries))
+			}
+
+			entry := logger.entries[0]
+			if entry.level != test.wantLevel {
+				t.Errorf("got level %s, want %s", entry.level, test.wantLevel)
+			}
+			if entry.args["status"] != test.status || entry.args["endpoint"] != ProjectsEndpoint {
+				t.Errorf("got status %v and endpoint %v", entry.args["status"], entry.args["endpoint"])
+			}
+			if entry.args["request_id"] == "" {
+				t.Error("got no request id")
+			}
+			if body := fmt.Sprint(entry.args["response_body"]); strings.Contains(body, "secret-token") {
+				t.Errorf("response body leaks the token: %s", body)
+			}
+		})
+	}
+}
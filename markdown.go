@@ -0,0 +1,523 @@
+package todoist
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type MarkdownProject struct {
+	Name     string
+	Comments []string
+	Items    []*MarkdownItem
+	Sections []*MarkdownSection
+}
+
+type MarkdownSection struct {
+	Name  string
+	Items []*MarkdownItem
+}
+
+type MarkdownItem struct {
+	Content     string
+	Description string
+	Completed   bool
+	Labels      []string
+	Priority    int
+	Due         string
+	Comments    []string
+	Children    []*MarkdownItem
+}
+
+var markdownItemPattern = regexp.MustCompile(`^(\s*)[-*+] \[([ xX])] (.*)$`)
+var markdownTokenPattern = regexp.MustCompile(`\s+(@\S+|p[1-4]|due:"[^"]*"|due:\S+)$`)
+
+// region WriteMarkdown
+
+func WriteMarkdown(w io.Writer, project *MarkdownProject) (err error) {
+	writer := bufio.NewWriter(w)
+
+	if _, err = fmt.Fprintf(writer, "# %s\n", project.Name); err != nil {
+		return
+	}
+
+	writeMarkdownComments(writer, "", project.Comments)
+	if len(project.Items) != 0 {
+		_ = writer.WriteByte('\n')
+		writeMarkdownItems(writer, "", project.Items)
+	}
+
+	for _, section := range project.Sections {
+		if _, err = fmt.Fprintf(writer, "\n## %s\n", section.Name); err != nil {
+			return
+		}
+
+		if len(section.Items) != 0 {
+			_ = writer.WriteByte('\n')
+			writeMarkdownItems(writer, "", section.Items)
+		}
+	}
+
+	return writer.Flush()
+}
+
+func writeMarkdownItems(writer *bufio.Writer, indent string, items []*MarkdownItem) {
+	for _, item := range items {
+		line := strings.Builder{}
+		line.WriteString(indent)
+		if item.Completed {
+			line.WriteString("- [x] ")
+		} else {
+			line.WriteString("- [ ] ")
+		}
+		line.WriteString(item.Content)
+
+		for _, label := range item.Labels {
+			line.WriteString(" @" + label)
+		}
+
+		if item.Priority > 1 && item.Priority <= 4 {
+			line.WriteString(" p" + strconv.Itoa(5-item.Priority))
+		}
+
+		if item.Due != "" {
+			if strings.ContainsAny(item.Due, " \t") {
+				line.WriteString(` due:"` + item.Due + `"`)
+			} else {
+				line.WriteString(" due:" + item.Due)
+			}
+		}
+
+		_, _ = writer.WriteString(line.String() + "\n")
+
+		if item.Description != "" {
+			for _, description := range strings.Split(item.Description, "\n") {
+				_, _ = writer.WriteString(strings.TrimRight(indent+"  "+description, " ") + "\n")
+			}
+		}
+
+		writeMarkdownComments(writer, indent+"  ", item.Comments)
+		writeMarkdownItems(writer, indent+"  ", item.Children)
+	}
+}
+
+func writeMarkdownComments(writer *bufio.Writer, indent string, comments []string) {
+	for _, comment := range comments {
+		for _, line := range strings.Split(comment, "\n") {
+			_, _ = writer.WriteString(strings.TrimRight(indent+"> "+line, " ") + "\n")
+		}
+	}
+}
+
+// endregion
+
+// region ParseMarkdown
+
+func ParseMarkdown(r io.Reader) (project *MarkdownProject, err error) {
+	project = &MarkdownProject{}
+
+	type level struct {
+		indent int
+		item   *MarkdownItem
+	}
+
+	var items *[]*MarkdownItem
+	items = &project.Items
+	stack := make([]level, 0)
+	lastComment := -1
+
+	// Blank lines are kept inside a description, which only ends at another kind of line.
+	var lastDescription *MarkdownItem
+	blankLines := 0
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimSpace(line)
+		indent := len(strings.Replace(line, "\t", "    ", -1)) - len(strings.Replace(strings.TrimLeft(line, " \t"), "\t", "    ", -1))
+
+		if trimmed == "" {
+			lastComment = -1
+			blankLines++
+			continue
+		}
+
+		description := lastDescription
+		lastDescription = nil
+		blank := blankLines
+		blankLines = 0
+
+		switch {
+		case strings.HasPrefix(line, "# "):
+			project.Name = strings.TrimSpace(line[2:])
+			stack = stack[:0]
+		case strings.HasPrefix(line, "## "):
+			section := &MarkdownSection{Name: strings.TrimSpace(line[3:])}
+			project.Sections = append(project.Sections, section)
+			items = &section.Items
+			stack = stack[:0]
+		case markdownItemPattern.MatchString(line):
+			match := markdownItemPattern.FindStringSubmatch(line)
+			item := parseMarkdownItem(match[3])
+			item.Completed = match[2] != " "
+
+			for len(stack) != 0 && stack[len(stack)-1].indent >= indent {
+				stack = stack[:len(stack)-1]
+			}
+
+			if len(stack) == 0 {
+				*items = append(*items, item)
+			} else {
+				parent := stack[len(stack)-1].item
+				parent.Children = append(parent.Children, item)
+			}
+
+			stack = append(stack, level{indent: indent, item: item})
+			lastComment = -1
+		case strings.HasPrefix(trimmed, ">"):
+			comment := strings.TrimPrefix(strings.TrimPrefix(trimmed, ">"), " ")
+
+			comments := &project.Comments
+			for len(stack) != 0 && stack[len(stack)-1].indent >= indent {
+				stack = stack[:len(stack)-1]
+			}
+			if len(stack) != 0 {
+				comments = &stack[len(stack)-1].item.Comments
+			}
+
+			if lastComment != -1 && lastComment == len(*comments)-1 {
+				(*comments)[lastComment] += "\n" + comment
+			} else {
+				*comments = append(*comments, comment)
+				lastComment = len(*comments) - 1
+			}
+		default:
+			for len(stack) != 0 && stack[len(stack)-1].indent >= indent {
+				stack = stack[:len(stack)-1]
+			}
+			if len(stack) == 0 {
+				continue
+			}
+
+			item := stack[len(stack)-1].item
+			if item == description {
+				item.Description += strings.Repeat("\n", blank+1)
+			} else if item.Description != "" {
+				item.Description += "\n"
+			}
+			item.Description += trimmed
+			lastDescription = item
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func parseMarkdownItem(text string) *MarkdownItem {
+	item := &MarkdownItem{Priority: 1}
+
+	text = " " + text
+	for {
+		match := markdownTokenPattern.FindStringSubmatchIndex(text)
+		if match == nil {
+			break
+		}
+
+		token := text[match[2]:match[3]]
+		text = text[:match[0]]
+
+		switch {
+		case strings.HasPrefix(token, "@"):
+			item.Labels = append([]string{token[1:]}, item.Labels...)
+		case strings.HasPrefix(token, "due:"):
+			item.Due = strings.Trim(token[4:], `"`)
+		default:
+			item.Priority = 5 - int(token[1]-'0')
+		}
+	}
+
+	item.Content = strings.TrimSpace(text)
+
+	return item
+}
+
+// endregion
+
+// region ExportProjectMarkdown
+
+func (t *Todoist) MakeMarkdownProject(ctx context.Context, projectId int) (project *MarkdownProject, err error) {
+	var source *Project
+	if source, err = t.GetProject(ctx, projectId); err != nil {
+		return
+	}
+
+	var sections []Section
+	if sections, err = t.GetSections(ctx, MakeGetSectionsParams().WithProjectId(projectId)); err != nil {
+		return
+	}
+
+	var tasks []Task
+	if tasks, err = t.GetTasks(ctx, MakeGetTasksParams().WithProjectId(projectId)); err != nil {
+		return
+	}
+
+	var labels []Label
+	if labels, err = t.GetLabels(ctx); err != nil {
+		return
+	}
+
+	labelNames := make(map[int]string, len(labels))
+	for _, label := range labels {
+		labelNames[label.Id] = label.Name
+	}
+
+	project = &MarkdownProject{Name: source.Name}
+	if source.CommentCount != 0 {
+		if project.Comments, err = t.getCommentContents(ctx, MakeGetCommentsParams().WithProjectId(projectId)); err != nil {
+			return
+		}
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].Order < tasks[j].Order
+	})
+
+	items := make(map[int]*MarkdownItem, len(tasks))
+	for _, task := range tasks {
+		item := &MarkdownItem{
+			Content:     task.Content,
+			Description: task.Description,
+			Completed:   task.Completed,
+			Priority:    task.Priority,
+			Due:         task.Due.String,
+		}
+
+		for _, labelId := range task.LabelIds {
+			if name, ok := labelNames[labelId]; ok {
+				item.Labels = append(item.Labels, name)
+			}
+		}
+
+		if task.CommentCount != 0 {
+			if item.Comments, err = t.getCommentContents(ctx, MakeGetCommentsParams().WithTaskId(task.Id)); err != nil {
+				return
+			}
+		}
+
+		items[task.Id] = item
+	}
+
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sections[i].Order < sections[j].Order
+	})
+
+	bySection := make(map[int]*MarkdownSection, len(sections))
+	for _, section := range sections {
+		bySection[section.Id] = &MarkdownSection{Name: section.Name}
+		project.Sections = append(project.Sections, bySection[section.Id])
+	}
+
+	for _, task := range tasks {
+		item := items[task.Id]
+		if parent, ok := items[task.ParentId]; ok && task.ParentId != 0 {
+			parent.Children = append(parent.Children, item)
+		} else if section, ok := bySection[task.SectionId]; ok {
+			section.Items = append(section.Items, item)
+		} else {
+			project.Items = append(project.Items, item)
+		}
+	}
+
+	return
+}
+
+func (t *Todoist) ExportProjectMarkdown(ctx context.Context, projectId int, w io.Writer) (err error) {
+	var project *MarkdownProject
+	if project, err = t.MakeMarkdownProject(ctx, projectId); err != nil {
+		return
+	}
+
+	return WriteMarkdown(w, project)
+}
+
+func (t *Todoist) getCommentContents(ctx context.Context, params *GetCommentsParams) (contents []string, err error) {
+	var comments []Comment
+	if comments, err = t.GetComments(ctx, params); err != nil {
+		return
+	}
+
+	contents = make([]string, 0, len(comments))
+	for _, comment := range comments {
+		contents = append(contents, comment.Content)
+	}
+
+	return
+}
+
+// endregion
+
+// region ImportProjectMarkdown
+
+// ImportProjectMarkdown creates a project from the document when projectId is zero,
+// otherwise it syncs the document into the existing project: missing sections and
+// tasks are created, existing tasks with the same content are updated and closed when
+// checked. Comments are only added to newly created tasks and projects.
+func (t *Todoist) ImportProjectMarkdown(ctx context.Context, projectId int, r io.Reader) (id int, err error) {
+	var document *MarkdownProject
+	if document, err = ParseMarkdown(r); err != nil {
+		return
+	}
+
+	created := false
+	if projectId == 0 {
+		if document.Name == "" {
+			return 0, errors.New("missing project name")
+		}
+
+		var project *Project
+		if project, err = t.AddProject(ctx, MakeAddProjectParams().WithName(document.Name)); err != nil {
+			return
+		}
+
+		projectId, created = project.Id, true
+	}
+
+	importer := &markdownImporter{
+		todoist:   t,
+		resolver:  NewResolver(t, 0),
+		projectId: projectId,
+		existing:  make(map[string]Task),
+	}
+
+	if !created {
+		var tasks []Task
+		if tasks, err = t.GetTasks(ctx, MakeGetTasksParams().WithProjectId(projectId)); err != nil {
+			return
+		}
+
+		for _, task := range tasks {
+			importer.existing[markdownTaskKey(task.SectionId, task.ParentId, task.Content)] = task
+		}
+	} else {
+		for _, comment := range document.Comments {
+			if _, err = t.AddComment(ctx, MakeAddCommentParams().WithProjectId(projectId).WithContent(comment)); err != nil {
+				return
+			}
+		}
+	}
+
+	if err = importer.items(ctx, 0, 0, document.Items); err != nil {
+		return projectId, err
+	}
+
+	for _, section := range document.Sections {
+		var target *Section
+		if target, err = importer.resolver.GetOrCreateSection(ctx, projectId, section.Name); err != nil {
+			return projectId, err
+		}
+
+		if err = importer.items(ctx, target.Id, 0, section.Items); err != nil {
+			return projectId, err
+		}
+	}
+
+	return projectId, nil
+}
+
+type markdownImporter struct {
+	todoist   *Todoist
+	resolver  *Resolver
+	projectId int
+	existing  map[string]Task
+}
+
+func (i *markdownImporter) items(ctx context.Context, sectionId int, parentId int, items []*MarkdownItem) (err error) {
+	for _, item := range items {
+		labelIds := make([]int, 0, len(item.Labels))
+		for _, name := range item.Labels {
+			var label *Label
+			if label, err = i.resolver.GetOrCreateLabel(ctx, name); err != nil {
+				return
+			}
+			labelIds = append(labelIds, label.Id)
+		}
+
+		var taskId int
+		if task, ok := i.existing[markdownTaskKey(sectionId, parentId, item.Content)]; ok {
+			taskId = task.Id
+
+			params := MakeUpdateTaskParams().
+				WithDescription(item.Description).
+				WithLabelIds(labelIds).
+				WithPriority(item.Priority).
+				WithDueString(item.Due)
+
+			if err = i.todoist.UpdateTask(ctx, taskId, params); err != nil {
+				return fmt.Errorf("update %q: %w", item.Content, err)
+			}
+		} else {
+			if item.Completed {
+				continue
+			}
+
+			params := MakeAddTaskParams().
+				WithContent(item.Content).
+				WithDescription(item.Description).
+				WithProjectId(i.projectId).
+				WithLabelIds(labelIds).
+				WithPriority(item.Priority).
+				WithDueString(item.Due)
+
+			if parentId != 0 {
+				params.WithParentId(parentId)
+			} else {
+				params.WithSectionId(sectionId)
+			}
+
+			var added *Task
+			if added, err = i.todoist.AddTask(ctx, params); err != nil {
+				return fmt.Errorf("add %q: %w", item.Content, err)
+			}
+			taskId = added.Id
+
+			for _, comment := range item.Comments {
+				if _, err = i.todoist.AddComment(ctx, MakeAddCommentParams().WithTaskId(taskId).WithContent(comment)); err != nil {
+					return
+				}
+			}
+		}
+
+		if err = i.items(ctx, sectionId, taskId, item.Children); err != nil {
+			return
+		}
+
+		if item.Completed {
+			if err = i.todoist.CloseTask(ctx, taskId); err != nil {
+				return fmt.Errorf("close %q: %w", item.Content, err)
+			}
+		}
+	}
+
+	return
+}
+
+// Subtasks report the section of their parent, so the key does not depend on it.
+func markdownTaskKey(sectionId int, parentId int, content string) string {
+	if parentId != 0 {
+		sectionId = 0
+	}
+
+	return strconv.Itoa(sectionId) + ":" + strconv.Itoa(parentId) + ":" + content
+}
+
+// endregion
@@ -0,0 +1,44 @@
+package todoist
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	project := &MarkdownProject{
+		Name:     "Home",
+		Comments: []string{"first\n\nsecond"},
+		Items: []*MarkdownItem{
+			{
+				Content:     "Paint the fence",
+				Description: "line1\n\nline3",
+				Labels:      []string{"weekend"},
+				Priority:    3,
+				Due:         "next saturday",
+				Comments:    []string{"white or green?"},
+				Children: []*MarkdownItem{
+					{Content: "Buy paint", Description: "two\n\n\nparagraphs", Completed: true, Priority: 1},
+				},
+			},
+		},
+		Sections: []*MarkdownSection{
+			{Name: "Garden", Items: []*MarkdownItem{{Content: "Mow the lawn", Priority: 1}}},
+		},
+	}
+
+	var buffer bytes.Buffer
+	if err := WriteMarkdown(&buffer, project); err != nil {
+		t.Fatalf("write: %s", err)
+	}
+
+	parsed, err := ParseMarkdown(&buffer)
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+
+	if !reflect.DeepEqual(parsed, project) {
+		t.Errorf("round trip changed the project:\n%s", buffer.String())
+	}
+}
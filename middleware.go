@@ -0,0 +1,74 @@
+package todoist
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Request describes a single API call. Data receives the decoded response and
+// StatusCode the last response status, so middlewares can inspect them once the
+// next handler returns. Header values override the default ones, including the
+// Authorization header.
+type Request struct {
+	Operation  string
+	Method     string
+	BaseUrl    string
+	Endpoint   string
+	Params     map[string]string
+	Payload    []byte
+	Header     http.Header
+	Data       interface{}
+	StatusCode int
+	Retries    int
+}
+
+type Handler func(ctx context.Context, req *Request) error
+
+// Middleware wraps the request path. The first middleware in Opts.Middlewares
+// is the outermost one and retries happen inside the innermost handler.
+type Middleware func(next Handler) Handler
+
+// region LoggingMiddleware
+
+//goland:noinspection GoUnusedExportedFunction
+func LoggingMiddleware(logger *log.Logger) Middleware {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (err error) {
+			start := time.Now()
+			err = next(ctx, req)
+
+			if err != nil {
+				logger.Printf("todoist: %s %s failed after %s: %s", req.Method, req.Endpoint, time.Since(start), err)
+			} else {
+				logger.Printf("todoist: %s %s done in %s", req.Method, req.Endpoint, time.Since(start))
+			}
+
+			return
+		}
+	}
+}
+
+// endregion
+
+// region TimingMiddleware
+
+//goland:noinspection GoUnusedExportedFunction
+func TimingMiddleware(observe func(req *Request, duration time.Duration, err error)) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (err error) {
+			start := time.Now()
+			err = next(ctx, req)
+			observe(req, time.Since(start), err)
+
+			return
+		}
+	}
+}
+
+// endregion
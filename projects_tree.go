@@ -0,0 +1,238 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+)
+
+const ProjectPathSeparator = "/"
+
+type ProjectNode struct {
+	Project
+
+	Parent   *ProjectNode
+	Children []*ProjectNode
+}
+
+type ProjectTree struct {
+	Roots []*ProjectNode
+
+	nodes map[int]*ProjectNode
+}
+
+// region ProjectNode
+
+func (n *ProjectNode) Depth() (depth int) {
+	for node := n.Parent; node != nil; node = node.Parent {
+		depth++
+	}
+
+	return
+}
+
+func (n *ProjectNode) Path() string {
+	names := make([]string, n.Depth()+1)
+	for node, i := n, len(names)-1; node != nil; node, i = node.Parent, i-1 {
+		names[i] = node.Name
+	}
+
+	return strings.Join(names, ProjectPathSeparator)
+}
+
+func (n *ProjectNode) Walk(fn func(node *ProjectNode) bool) bool {
+	if !fn(n) {
+		return false
+	}
+
+	for _, child := range n.Children {
+		if !child.Walk(fn) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// endregion
+
+// region ProjectTree
+
+func MakeProjectTree(projects []Project) *ProjectTree {
+	tree := &ProjectTree{
+		Roots: make([]*ProjectNode, 0),
+		nodes: make(map[int]*ProjectNode, len(projects)),
+	}
+
+	for _, project := range projects {
+		tree.nodes[project.Id] = &ProjectNode{
+			Project:  project,
+			Children: make([]*ProjectNode, 0),
+		}
+	}
+
+	for _, project := range projects {
+		node := tree.nodes[project.Id]
+		if parent, ok := tree.nodes[project.ParentId]; ok && project.ParentId != 0 {
+			node.Parent = parent
+			parent.Children = append(parent.Children, node)
+		} else {
+			tree.Roots = append(tree.Roots, node)
+		}
+	}
+
+	sortProjectNodes(tree.Roots)
+	for _, node := range tree.nodes {
+		sortProjectNodes(node.Children)
+	}
+
+	return tree
+}
+
+func (t *ProjectTree) Node(projectId int) *ProjectNode {
+	return t.nodes[projectId]
+}
+
+func (t *ProjectTree) Path(projectId int) string {
+	if node, ok := t.nodes[projectId]; ok {
+		return node.Path()
+	}
+
+	return ""
+}
+
+func (t *ProjectTree) Depth(projectId int) int {
+	if node, ok := t.nodes[projectId]; ok {
+		return node.Depth()
+	}
+
+	return -1
+}
+
+func (t *ProjectTree) Lookup(path string) *ProjectNode {
+	nodes := t.Roots
+
+	var found *ProjectNode
+	for _, name := range splitProjectPath(path) {
+		found = nil
+		for _, node := range nodes {
+			if node.Name == name {
+				found = node
+				break
+			}
+		}
+
+		if found == nil {
+			return nil
+		}
+
+		nodes = found.Children
+	}
+
+	return found
+}
+
+func (t *ProjectTree) Walk(fn func(node *ProjectNode) bool) {
+	for _, root := range t.Roots {
+		if !root.Walk(fn) {
+			return
+		}
+	}
+}
+
+func (t *ProjectTree) insert(project Project) *ProjectNode {
+	node := &ProjectNode{
+		Project:  project,
+		Children: make([]*ProjectNode, 0),
+	}
+	t.nodes[project.Id] = node
+
+	if parent, ok := t.nodes[project.ParentId]; ok && project.ParentId != 0 {
+		node.Parent = parent
+		parent.Children = append(parent.Children, node)
+		sortProjectNodes(parent.Children)
+	} else {
+		t.Roots = append(t.Roots, node)
+		sortProjectNodes(t.Roots)
+	}
+
+	return node
+}
+
+func sortProjectNodes(nodes []*ProjectNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].Order < nodes[j].Order
+	})
+}
+
+func splitProjectPath(path string) []string {
+	names := make([]string, 0)
+	for _, name := range strings.Split(path, ProjectPathSeparator) {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// endregion
+
+// region GetProjectTree
+
+func (t *Todoist) GetProjectTree(ctx context.Context) (tree *ProjectTree, err error) {
+	var projects []Project
+	if projects, err = t.GetProjects(ctx); err != nil {
+		return
+	}
+
+	return MakeProjectTree(projects), nil
+}
+
+// endregion
+
+// region EnsureProjectPath
+
+func (t *Todoist) EnsureProjectPath(ctx context.Context, path string) (project *Project, err error) {
+	names := splitProjectPath(path)
+	if len(names) == 0 {
+		return nil, errors.New("empty project path")
+	}
+
+	var tree *ProjectTree
+	if tree, err = t.GetProjectTree(ctx); err != nil {
+		return
+	}
+
+	var parent *ProjectNode
+	for i, name := range names {
+		var node *ProjectNode
+		if node = tree.Lookup(strings.Join(names[:i+1], ProjectPathSeparator)); node == nil {
+			params := MakeAddProjectParams().WithName(name)
+			if parent != nil {
+				params.WithParentId(parent.Id)
+			}
+
+			var added *Project
+			if added, err = t.AddProject(ctx, params); err != nil {
+				return
+			}
+
+			if parent != nil {
+				added.ParentId = parent.Id
+			}
+
+			node = tree.insert(*added)
+		}
+
+		parent = node
+	}
+
+	project = new(Project)
+	*project = parent.Project
+
+	return
+}
+
+// endregion
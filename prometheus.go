@@ -0,0 +1,159 @@
+package todoist
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultPrometheusBuckets are the latency buckets in seconds.
+var DefaultPrometheusBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// PrometheusMeter is a Meter that keeps the values in memory and writes them
+// in the Prometheus text exposition format.
+type PrometheusMeter struct {
+	mutex      sync.Mutex
+	buckets    []float64
+	counters   map[string]map[string]float64
+	histograms map[string]map[string]*prometheusHistogram
+}
+
+type prometheusHistogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func NewPrometheusMeter(buckets []float64) *PrometheusMeter {
+	if len(buckets) == 0 {
+		buckets = DefaultPrometheusBuckets
+	}
+
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	return &PrometheusMeter{
+		buckets:    buckets,
+		counters:   make(map[string]map[string]float64),
+		histograms: make(map[string]map[string]*prometheusHistogram),
+	}
+}
+
+func (m *PrometheusMeter) AddCounter(name string, value float64, labels map[string]string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	series, ok := m.counters[name]
+	if !ok {
+		series = make(map[string]float64)
+		m.counters[name] = series
+	}
+
+	series[prometheusLabels(labels)] += value
+}
+
+func (m *PrometheusMeter) RecordHistogram(name string, value float64, labels map[string]string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	series, ok := m.histograms[name]
+	if !ok {
+		series = make(map[string]*prometheusHistogram)
+		m.histograms[name] = series
+	}
+
+	key := prometheusLabels(labels)
+	histogram, ok := series[key]
+	if !ok {
+		histogram = &prometheusHistogram{counts: make([]uint64, len(m.buckets))}
+		series[key] = histogram
+	}
+
+	for i, bound := range m.buckets {
+		if value <= bound {
+			histogram.counts[i]++
+		}
+	}
+	histogram.count++
+	histogram.sum += value
+}
+
+func (m *PrometheusMeter) WriteTo(w io.Writer) (n int64, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var b strings.Builder
+
+	for _, name := range sortedKeys(m.counters) {
+		_, _ = fmt.Fprintf(&b, "# TYPE %s counter\n", name)
+		for _, labels := range sortedKeys(m.counters[name]) {
+			_, _ = fmt.Fprintf(&b, "%s%s %s\n", name, labels, formatPrometheusValue(m.counters[name][labels]))
+		}
+	}
+
+	for _, name := range sortedKeys(m.histograms) {
+		_, _ = fmt.Fprintf(&b, "# TYPE %s histogram\n", name)
+		for _, labels := range sortedKeys(m.histograms[name]) {
+			histogram := m.histograms[name][labels]
+			for i, bound := range m.buckets {
+				_, _ = fmt.Fprintf(&b, "%s_bucket%s %d\n", name, withPrometheusLabel(labels, "le", formatPrometheusValue(bound)), histogram.counts[i])
+			}
+			_, _ = fmt.Fprintf(&b, "%s_bucket%s %d\n", name, withPrometheusLabel(labels, "le", "+Inf"), histogram.count)
+			_, _ = fmt.Fprintf(&b, "%s_sum%s %s\n", name, labels, formatPrometheusValue(histogram.sum))
+			_, _ = fmt.Fprintf(&b, "%s_count%s %d\n", name, labels, histogram.count)
+		}
+	}
+
+	written, err := io.WriteString(w, b.String())
+
+	return int64(written), err
+}
+
+// ServeHTTP exposes the metrics, so the meter can be mounted at /metrics.
+func (m *PrometheusMeter) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = m.WriteTo(w)
+}
+
+func prometheusLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(labels))
+	for _, key := range sortedKeys(labels) {
+		pairs = append(pairs, key+"="+strconv.Quote(labels[key]))
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func withPrometheusLabel(labels string, key string, value string) string {
+	pair := key + "=" + strconv.Quote(value)
+	if labels == "" {
+		return "{" + pair + "}"
+	}
+
+	return labels[:len(labels)-1] + "," + pair + "}"
+}
+
+func formatPrometheusValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// sortedKeys returns the sorted keys of a map with string keys.
+func sortedKeys(m interface{}) []string {
+	keys := make([]string, 0)
+	for _, key := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,308 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type QuickAdd struct {
+	Content   string
+	Project   string
+	Section   string
+	Labels    []string
+	Priority  int
+	Assignee  string
+	DueString string
+}
+
+var quickAddPriorityPattern = regexp.MustCompile(`^[pP]([1-4])$`)
+
+var quickAddTimePattern = regexp.MustCompile(`^(\d{1,2}(:\d{2})?(am|pm)?|\d{1,2}(st|nd|rd|th)|\d{4}-\d{2}-\d{2}|\d{1,2}/\d{1,2}(/\d{2,4})?|\d{1,2}\.\d{1,2}\.\d{2,4})$`)
+
+// Decimals are numbers, so "10.5" only makes a due date next to a unit.
+var quickAddNumberPattern = regexp.MustCompile(`^\d+(\.\d+)?$`)
+
+// Words that make a due date on their own.
+var quickAddStrongWords = map[string]bool{
+	"today": true, "tod": true, "tomorrow": true, "tom": true, "tonight": true, "yesterday": true,
+	"noon": true, "midnight": true, "eod": true,
+	"monday": true, "tuesday": true, "wednesday": true, "thursday": true, "friday": true, "saturday": true, "sunday": true,
+	"mon": true, "tue": true, "tues": true, "wed": true, "thu": true, "thurs": true, "fri": true,
+	"sat": true, "sun": true,
+	"weekday": true, "weekend": true, "workday": true,
+}
+
+// Words that make a due date only when followed by a weekday, a unit or a
+// number, as in "next friday" or "every 2 weeks" but not "the next release".
+var quickAddModifiers = map[string]bool{
+	"next": true, "every": true, "every!": true,
+}
+
+// Words that only make a due date together with a number, such as "in 3 days" or "jan 5".
+var quickAddWeakWords = map[string]bool{
+	"day": true, "days": true, "week": true, "weeks": true, "month": true, "months": true, "year": true, "years": true,
+	"hour": true, "hours": true, "minute": true, "minutes": true,
+	"january": true, "february": true, "march": true, "april": true, "june": true, "july": true,
+	"august": true, "september": true, "october": true, "november": true, "december": true,
+	"jan": true, "feb": true, "mar": true, "apr": true, "jun": true, "jul": true, "aug": true, "sep": true, "sept": true,
+	"oct": true, "nov": true, "dec": true,
+	"morning": true, "afternoon": true, "evening": true, "night": true, "am": true, "pm": true,
+	"this": true, "other": true,
+}
+
+// Words that can be part of a due date but never start or end one on their own.
+var quickAddConnectors = map[string]bool{
+	"at": true, "in": true, "on": true, "from": true, "starting": true, "until": true, "ending": true,
+	"after": true, "before": true, "for": true, "and": true, "of": true, "the": true, "by": true,
+}
+
+// Connectors that are kept at the start of a due date, as in "in 3 days".
+var quickAddLeadingConnectors = map[string]bool{
+	"at": true, "in": true, "on": true,
+}
+
+// region ParseQuickAdd
+
+// ParseQuickAdd extracts "#Project", "/Section", "@label", "p1".."p4", "+assignee"
+// and a due date phrase from the text, leaving the rest as the task content.
+// It does not call the API, so names are returned as typed.
+func ParseQuickAdd(text string) *QuickAdd {
+	quickAdd := &QuickAdd{
+		Labels: make([]string, 0),
+	}
+
+	words := make([]string, 0)
+	for _, word := range strings.Fields(text) {
+		switch {
+		case len(word) > 1 && word[0] == '#':
+			quickAdd.Project = word[1:]
+		case len(word) > 1 && word[0] == '/' && !quickAddTimePattern.MatchString(word[1:]):
+			quickAdd.Section = word[1:]
+		case len(word) > 1 && word[0] == '@':
+			quickAdd.Labels = append(quickAdd.Labels, word[1:])
+		case len(word) > 1 && word[0] == '+':
+			quickAdd.Assignee = word[1:]
+		case quickAddPriorityPattern.MatchString(word):
+			quickAdd.Priority = 5 - int(word[1]-'0')
+		default:
+			words = append(words, word)
+		}
+	}
+
+	start, end := findDuePhrase(words)
+	if start != end {
+		quickAdd.DueString = strings.Join(words[start:end], " ")
+		words = append(words[:start:start], words[end:]...)
+	}
+
+	quickAdd.Content = strings.Join(words, " ")
+
+	return quickAdd
+}
+
+// findDuePhrase returns the longest run of date words that contains a strong
+// word or a number with a unit, preferring the last one on ties.
+func findDuePhrase(words []string) (start int, end int) {
+	kinds := quickAddWordKinds(words)
+
+	for i := 0; i < len(words); {
+		if kinds[i] == "" {
+			i++
+			continue
+		}
+
+		j := i
+		strong, number, weak := false, false, false
+		for ; j < len(words); j++ {
+			kind := kinds[j]
+			if kind == "" {
+				break
+			}
+
+			strong = strong || kind == "strong" || kind == "time"
+			number = number || kind == "number" || kind == "time"
+			weak = weak || kind == "weak"
+		}
+
+		runStart, runEnd := i, j
+		for runStart < runEnd && kinds[runStart] == "connector" && !quickAddLeadingConnectors[strings.ToLower(words[runStart])] {
+			runStart++
+		}
+		for runStart < runEnd && kinds[runEnd-1] == "connector" {
+			runEnd--
+		}
+
+		if (strong || (number && weak)) && runEnd-runStart >= end-start {
+			start, end = runStart, runEnd
+		}
+
+		i = j
+	}
+
+	return
+}
+
+// quickAddWordKinds classifies every word, looking at the next word for modifiers.
+func quickAddWordKinds(words []string) []string {
+	kinds := make([]string, len(words))
+	for i := len(words) - 1; i >= 0; i-- {
+		kinds[i] = quickAddWordKind(words[i])
+		if kinds[i] != "modifier" {
+			continue
+		}
+
+		kinds[i] = ""
+		if i+1 < len(words) && kinds[i+1] != "" && kinds[i+1] != "connector" {
+			kinds[i] = "strong"
+		}
+	}
+
+	return kinds
+}
+
+func quickAddWordKind(word string) string {
+	word = strings.ToLower(strings.TrimRight(word, ",."))
+
+	switch {
+	case quickAddModifiers[word]:
+		return "modifier"
+	case quickAddStrongWords[word]:
+		return "strong"
+	case quickAddWeakWords[word]:
+		return "weak"
+	case quickAddConnectors[word]:
+		return "connector"
+	case quickAddNumberPattern.MatchString(word):
+		return "number"
+	case quickAddTimePattern.MatchString(word):
+		return "time"
+	default:
+		return ""
+	}
+}
+
+// endregion
+
+// region ResolveQuickAdd
+
+// ResolveQuickAdd turns names into ids. Sections without a project are looked up
+// in the inbox and missing labels are created, like the Todoist quick add does.
+func (r *Resolver) ResolveQuickAdd(ctx context.Context, quickAdd *QuickAdd) (params *AddTaskParams, err error) {
+	if quickAdd.Content == "" {
+		return nil, errors.New("empty task content")
+	}
+
+	params = MakeAddTaskParams().
+		WithContent(quickAdd.Content).
+		WithPriority(quickAdd.Priority).
+		WithDueString(quickAdd.DueString)
+
+	projectId := 0
+	if quickAdd.Project != "" {
+		var project *Project
+		if project, err = r.ProjectByName(ctx, quickAdd.Project); err != nil {
+			return
+		}
+
+		projectId = project.Id
+		params.WithProjectId(projectId)
+	}
+
+	if quickAdd.Section != "" {
+		if projectId == 0 {
+			if projectId, err = r.inboxId(ctx); err != nil {
+				return
+			}
+		}
+
+		var section *Section
+		if section, err = r.SectionByName(ctx, projectId, quickAdd.Section); err != nil {
+			return
+		}
+
+		params.WithSectionId(section.Id)
+	}
+
+	labelIds := make([]int, 0, len(quickAdd.Labels))
+	for _, name := range quickAdd.Labels {
+		var label *Label
+		if label, err = r.GetOrCreateLabel(ctx, name); err != nil {
+			return
+		}
+
+		labelIds = append(labelIds, label.Id)
+	}
+	params.WithLabelIds(labelIds)
+
+	if quickAdd.Assignee != "" {
+		if projectId == 0 {
+			return nil, fmt.Errorf("assignee %q: tasks can only be assigned in shared projects", quickAdd.Assignee)
+		}
+
+		var collaborators []Collaborator
+		if collaborators, err = r.todoist.GetCollaborators(ctx, projectId); err != nil {
+			return
+		}
+
+		var index int
+		if index, err = matchCollaboratorName(collaborators, quickAdd.Assignee); err != nil {
+			return
+		}
+
+		params.WithAssignee(collaborators[index].Id)
+	}
+
+	return
+}
+
+func (r *Resolver) inboxId(ctx context.Context) (projectId int, err error) {
+	var projects []Project
+	if projects, err = r.Projects(ctx); err != nil {
+		return
+	}
+
+	for _, project := range projects {
+		if project.InboxProject {
+			return project.Id, nil
+		}
+	}
+
+	return 0, fmt.Errorf("inbox project: %w", ErrNotFound)
+}
+
+// Collaborators are matched by email, full name or first name.
+func matchCollaboratorName(collaborators []Collaborator, name string) (index int, err error) {
+	if index, err = matchName(len(collaborators), func(i int) string { return collaborators[i].Email }, name, "assignee"); !errors.Is(err, ErrNotFound) {
+		return
+	}
+
+	if index, err = matchName(len(collaborators), func(i int) string { return collaborators[i].Name }, name, "assignee"); !errors.Is(err, ErrNotFound) {
+		return
+	}
+
+	return matchName(len(collaborators), func(i int) string {
+		if fields := strings.Fields(collaborators[i].Name); len(fields) != 0 {
+			return fields[0]
+		}
+
+		return ""
+	}, name, "assignee")
+}
+
+// endregion
+
+// region QuickAddTask
+
+func (t *Todoist) QuickAddTask(ctx context.Context, text string) (task *Task, err error) {
+	var params *AddTaskParams
+	if params, err = NewResolver(t, 0).ResolveQuickAdd(ctx, ParseQuickAdd(text)); err != nil {
+		return
+	}
+
+	return t.AddTask(ctx, params)
+}
+
+// endregion
@@ -0,0 +1,76 @@
+package todoist
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQuickAdd(t *testing.T) {
+	tests := []struct {
+		text string
+		want QuickAdd
+	}{
+		{
+			text: "Buy milk tomorrow 5pm #Home /Errands @shopping p2 +alice",
+			want: QuickAdd{Content: "Buy milk", Project: "Home", Section: "Errands", Labels: []string{"shopping"}, Priority: 3, Assignee: "alice", DueString: "tomorrow 5pm"},
+		},
+		{
+			text: "Water plants every other day",
+			want: QuickAdd{Content: "Water plants", Labels: []string{}, DueString: "every other day"},
+		},
+		{
+			text: "Call mom next friday at 6pm",
+			want: QuickAdd{Content: "Call mom", Labels: []string{}, DueString: "next friday at 6pm"},
+		},
+		{
+			text: "Pay rent every 1st",
+			want: QuickAdd{Content: "Pay rent", Labels: []string{}, DueString: "every 1st"},
+		},
+		{
+			text: "Review budget in 3 days",
+			want: QuickAdd{Content: "Review budget", Labels: []string{}, DueString: "in 3 days"},
+		},
+		{
+			text: "Plan the next release",
+			want: QuickAdd{Content: "Plan the next release", Labels: []string{}},
+		},
+		{
+			text: "Check every item",
+			want: QuickAdd{Content: "Check every item", Labels: []string{}},
+		},
+		{
+			text: "Read chapter 3 of the book",
+			want: QuickAdd{Content: "Read chapter 3 of the book", Labels: []string{}},
+		},
+		{
+			text: "Pick the next one tomorrow",
+			want: QuickAdd{Content: "Pick the next one", Labels: []string{}, DueString: "tomorrow"},
+		},
+		{
+			text: "Clean the garage sat",
+			want: QuickAdd{Content: "Clean the garage", Labels: []string{}, DueString: "sat"},
+		},
+		{
+			text: "Brunch sun 11am",
+			want: QuickAdd{Content: "Brunch", Labels: []string{}, DueString: "sun 11am"},
+		},
+		{
+			text: "Buy 10.5 kg of flour",
+			want: QuickAdd{Content: "Buy 10.5 kg of flour", Labels: []string{}},
+		},
+		{
+			text: "Run 2.5 miles",
+			want: QuickAdd{Content: "Run 2.5 miles", Labels: []string{}},
+		},
+		{
+			text: "Submit the report 10.5.2024",
+			want: QuickAdd{Content: "Submit the report", Labels: []string{}, DueString: "10.5.2024"},
+		},
+	}
+
+	for _, test := range tests {
+		if got := ParseQuickAdd(test.text); !reflect.DeepEqual(*got, test.want) {
+			t.Errorf("ParseQuickAdd(%q) = %+v, want %+v", test.text, *got, test.want)
+		}
+	}
+}
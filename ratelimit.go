@@ -0,0 +1,160 @@
+package todoist
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// The REST API allows 450 requests per 15 minutes for a single user.
+const DefaultRateLimit = 450.0 / (15 * 60)
+const DefaultRateBurst = 50
+
+// RateLimiter is a token bucket that halves its rate on every 429 response,
+// pausing for the Retry-After delay, and slowly recovers on successful requests.
+type RateLimiter struct {
+	mutex       sync.Mutex
+	rate        float64
+	maxRate     float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+var sharedRateLimiters = struct {
+	sync.Mutex
+	limiters map[string]*RateLimiter
+}{
+	limiters: make(map[string]*RateLimiter),
+}
+
+// NewRateLimiter creates a limiter allowing rate requests per second on average
+// with bursts of up to burst requests.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if rate <= 0 {
+		rate = DefaultRateLimit
+	}
+
+	if burst <= 0 {
+		burst = DefaultRateBurst
+	}
+
+	return &RateLimiter{
+		rate:    rate,
+		maxRate: rate,
+		burst:   float64(burst),
+		tokens:  float64(burst),
+		last:    time.Now(),
+	}
+}
+
+// SharedRateLimiter returns the same limiter for every Todoist instance using the token.
+// The rate and burst only apply to the first call for a token.
+//
+//goland:noinspection GoUnusedExportedFunction
+func SharedRateLimiter(token string, rate float64, burst int) *RateLimiter {
+	hash := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(hash[:])
+
+	sharedRateLimiters.Lock()
+	defer sharedRateLimiters.Unlock()
+
+	limiter, ok := sharedRateLimiters.limiters[key]
+	if !ok {
+		limiter = NewRateLimiter(rate, burst)
+		sharedRateLimiters.limiters[key] = limiter
+	}
+
+	return limiter
+}
+
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		l.mutex.Lock()
+		now := time.Now()
+		l.refill(now)
+
+		var delay time.Duration
+		if now.Before(l.pausedUntil) {
+			delay = l.pausedUntil.Sub(now)
+		} else if l.tokens >= 1 {
+			l.tokens--
+			l.mutex.Unlock()
+
+			return nil
+		} else {
+			delay = time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		}
+		l.mutex.Unlock()
+
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+func (l *RateLimiter) Backoff(retryAfter time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	l.refill(now)
+
+	if until := now.Add(retryAfter); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+
+	l.tokens = 0
+	if l.rate /= 2; l.rate < l.maxRate/16 {
+		l.rate = l.maxRate / 16
+	}
+}
+
+func (l *RateLimiter) Success() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.rate += l.maxRate / 32; l.rate > l.maxRate {
+		l.rate = l.maxRate
+	}
+}
+
+// Budget returns the number of requests that can be made right away.
+func (l *RateLimiter) Budget() float64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	if now.Before(l.pausedUntil) {
+		return 0
+	}
+	l.refill(now)
+
+	return l.tokens
+}
+
+// Rate returns the current number of requests per second, lowered after 429 responses.
+func (l *RateLimiter) Rate() float64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.rate
+}
+
+// refill adds the tokens earned since the last call, leaving out the time spent paused.
+func (l *RateLimiter) refill(now time.Time) {
+	from := l.last
+	if from.Before(l.pausedUntil) {
+		from = l.pausedUntil
+	}
+
+	if now.After(from) {
+		if l.tokens += now.Sub(from).Seconds() * l.rate; l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+}
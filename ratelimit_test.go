@@ -0,0 +1,135 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	limiter := NewRateLimiter(1, 3)
+
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("wait %d: %s", i, err)
+		}
+	}
+
+	if budget := limiter.Budget(); budget >= 1 {
+		t.Errorf("budget after the burst: got %.2f, want less than 1", budget)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("wait without tokens: got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRateLimiterBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		backoffs int
+		success  int
+		want     float64
+	}{
+		{"halves the rate", 1, 0, 16},
+		{"keeps a sixteenth", 10, 0, 2},
+		{"recovers on success", 1, 8, 24},
+		{"never exceeds the initial rate", 1, 100, 32},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limiter := NewRateLimiter(32, 10)
+			for i := 0; i < test.backoffs; i++ {
+				limiter.Backoff(0)
+			}
+			for i := 0; i < test.success; i++ {
+				limiter.Success()
+			}
+
+			if got := limiter.Rate(); got != test.want {
+				t.Errorf("got rate %.2f, want %.2f", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRateLimiterPause(t *testing.T) {
+	limiter := NewRateLimiter(1000, 10)
+	limiter.Backoff(time.Hour)
+
+	if budget := limiter.Budget(); budget != 0 {
+		t.Errorf("budget while paused: got %.2f, want 0", budget)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("wait while paused: got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestSharedRateLimiter(t *testing.T) {
+	if SharedRateLimiter("first", 1, 1) != SharedRateLimiter("first", 2, 2) {
+		t.Error("got different limiters for the same token")
+	}
+
+	if SharedRateLimiter("first", 1, 1) == SharedRateLimiter("second", 1, 1) {
+		t.Error("got the same limiter for different tokens")
+	}
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRetries int
+		failures   int32
+		wantErr    bool
+		wantCalls  int32
+	}{
+		{"retries by default", 0, 3, false, 4},
+		{"gives up after the default", 0, 4, true, 4},
+		{"uses the configured retries", 1, 1, false, 2},
+		{"negative disables retries", -1, 1, true, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&calls, 1) <= test.failures {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte("[]"))
+			}))
+			defer server.Close()
+
+			client := New(&Opts{
+				Token:       "secret",
+				Client:      &http.Client{Transport: rewriteHost(http.DefaultTransport, server.URL)},
+				RateLimiter: NewRateLimiter(1000, 10),
+				MaxRetries:  test.maxRetries,
+			})
+
+			_, err := client.GetProjects(context.Background())
+			if (err != nil) != test.wantErr {
+				t.Errorf("got error %v, want error %t", err, test.wantErr)
+			}
+			if got := atomic.LoadInt32(&calls); got != test.wantCalls {
+				t.Errorf("got %d calls, want %d", got, test.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package todoist
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+type ReminderType string
+
+const (
+	ReminderRelative ReminderType = "relative"
+	ReminderAbsolute ReminderType = "absolute"
+	ReminderLocation ReminderType = "location"
+)
+
+const (
+	ReminderOnEnter = "on_enter"
+	ReminderOnLeave = "on_leave"
+)
+
+type Reminder struct {
+	Id           int          `json:"id"`
+	TaskId       int          `json:"item_id"`
+	NotifyUid    int          `json:"notify_uid"`
+	Type         ReminderType `json:"type"`
+	Service      string       `json:"service"`
+	Due          Due          `json:"due"`
+	MinuteOffset int          `json:"mm_offset"`
+	Name         string       `json:"name"`
+	LocLat       string       `json:"loc_lat"`
+	LocLong      string       `json:"loc_long"`
+	LocTrigger   string       `json:"loc_trigger"`
+	Radius       int          `json:"radius"`
+	IsDeleted    syncBool     `json:"is_deleted"`
+}
+
+// region GetReminders
+
+type GetRemindersParams map[string]string
+
+//goland:noinspection GoUnusedExportedFunction
+func MakeGetRemindersParams() *GetRemindersParams {
+	params := make(GetRemindersParams)
+	return &params
+}
+
+func (p *GetRemindersParams) WithTaskId(taskId int) *GetRemindersParams {
+	if taskId != 0 {
+		(*p)["item_id"] = strconv.Itoa(taskId)
+	}
+
+	return p
+}
+
+func (p *GetRemindersParams) WithType(reminderType ReminderType) *GetRemindersParams {
+	if reminderType != "" {
+		(*p)["type"] = string(reminderType)
+	}
+
+	return p
+}
+
+// GetReminders reads all reminders through the Sync API and filters them locally.
+func (t *Todoist) GetReminders(ctx context.Context, params *GetRemindersParams) (reminders []Reminder, err error) {
+	res := &struct {
+		Reminders []Reminder `json:"reminders"`
+	}{}
+
+	if err = t.syncRead(WithOperation(ctx, "GetReminders"), []string{"reminders"}, res); err != nil {
+		return
+	}
+
+	taskId, _ := strconv.Atoi((*params)["item_id"])
+	reminderType := ReminderType((*params)["type"])
+
+	reminders = make([]Reminder, 0, len(res.Reminders))
+	for _, reminder := range res.Reminders {
+		if reminder.IsDeleted || taskId != 0 && reminder.TaskId != taskId || reminderType != "" && reminder.Type != reminderType {
+			continue
+		}
+
+		reminders = append(reminders, reminder)
+	}
+
+	return
+}
+
+// endregion
+
+// region AddReminder
+
+type AddReminderParams map[string]interface{}
+
+//goland:noinspection GoUnusedExportedFunction
+func MakeAddReminderParams() *AddReminderParams {
+	params := make(AddReminderParams)
+	return &params
+}
+
+func (p *AddReminderParams) WithTaskId(taskId int) *AddReminderParams {
+	if taskId != 0 {
+		(*p)["item_id"] = taskId
+	}
+
+	return p
+}
+
+func (p *AddReminderParams) WithType(reminderType ReminderType) *AddReminderParams {
+	if reminderType != "" {
+		(*p)["type"] = reminderType
+	}
+
+	return p
+}
+
+func (p *AddReminderParams) WithNotifyUid(notifyUid int) *AddReminderParams {
+	if notifyUid != 0 {
+		(*p)["notify_uid"] = notifyUid
+	}
+
+	return p
+}
+
+func (p *AddReminderParams) WithService(service string) *AddReminderParams {
+	if service != "" {
+		(*p)["service"] = service
+	}
+
+	return p
+}
+
+// WithMinuteOffset sets how many minutes before the task due time a relative
+// reminder fires. It is always sent, since 0 fires at the due time.
+func (p *AddReminderParams) WithMinuteOffset(minuteOffset int) *AddReminderParams {
+	(*p)["mm_offset"] = minuteOffset
+	return p
+}
+
+func (p *AddReminderParams) WithDueDatetime(dueDatetime string) *AddReminderParams {
+	if dueDatetime != "" {
+		(*p)["due"] = map[string]string{"date": dueDatetime}
+	}
+
+	return p
+}
+
+func (p *AddReminderParams) WithDueString(dueString string) *AddReminderParams {
+	if dueString != "" {
+		(*p)["due"] = map[string]string{"string": dueString}
+	}
+
+	return p
+}
+
+func (p *AddReminderParams) WithName(name string) *AddReminderParams {
+	if name != "" {
+		(*p)["name"] = name
+	}
+
+	return p
+}
+
+func (p *AddReminderParams) WithLocation(lat string, long string) *AddReminderParams {
+	if lat != "" && long != "" {
+		(*p)["loc_lat"] = lat
+		(*p)["loc_long"] = long
+	}
+
+	return p
+}
+
+func (p *AddReminderParams) WithLocTrigger(locTrigger string) *AddReminderParams {
+	if locTrigger != "" {
+		(*p)["loc_trigger"] = locTrigger
+	}
+
+	return p
+}
+
+func (p *AddReminderParams) WithRadius(radius int) *AddReminderParams {
+	if radius != 0 {
+		(*p)["radius"] = radius
+	}
+
+	return p
+}
+
+// AddReminder returns the reminder as stored by the server, including the
+// fields it fills in, such as the computed due date of relative reminders.
+func (t *Todoist) AddReminder(ctx context.Context, params *AddReminderParams) (reminder *Reminder, err error) {
+	var id int
+	if id, err = t.syncAdd(WithOperation(ctx, "AddReminder"), "reminder_add", params); err != nil {
+		return
+	}
+
+	taskId, _ := (*params)["item_id"].(int)
+
+	var reminders []Reminder
+	if reminders, err = t.GetReminders(ctx, MakeGetRemindersParams().WithTaskId(taskId)); err != nil {
+		return
+	}
+
+	for i := range reminders {
+		if reminders[i].Id == id {
+			return &reminders[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("reminder_add: missing reminder %d", id)
+}
+
+// endregion
+
+// region UpdateReminder
+
+type UpdateReminderParams map[string]interface{}
+
+//goland:noinspection GoUnusedExportedFunction
+func MakeUpdateReminderParams() *UpdateReminderParams {
+	params := make(UpdateReminderParams)
+	return &params
+}
+
+func (p *UpdateReminderParams) WithNotifyUid(notifyUid int) *UpdateReminderParams {
+	if notifyUid != 0 {
+		(*p)["notify_uid"] = notifyUid
+	}
+
+	return p
+}
+
+func (p *UpdateReminderParams) WithType(reminderType ReminderType) *UpdateReminderParams {
+	if reminderType != "" {
+		(*p)["type"] = reminderType
+	}
+
+	return p
+}
+
+func (p *UpdateReminderParams) WithService(service string) *UpdateReminderParams {
+	if service != "" {
+		(*p)["service"] = service
+	}
+
+	return p
+}
+
+func (p *UpdateReminderParams) WithMinuteOffset(minuteOffset int) *UpdateReminderParams {
+	(*p)["mm_offset"] = minuteOffset
+	return p
+}
+
+func (p *UpdateReminderParams) WithDueDatetime(dueDatetime string) *UpdateReminderParams {
+	if dueDatetime != "" {
+		(*p)["due"] = map[string]string{"date": dueDatetime}
+	}
+
+	return p
+}
+
+func (p *UpdateReminderParams) WithDueString(dueString string) *UpdateReminderParams {
+	if dueString != "" {
+		(*p)["due"] = map[string]string{"string": dueString}
+	}
+
+	return p
+}
+
+func (p *UpdateReminderParams) WithName(name string) *UpdateReminderParams {
+	if name != "" {
+		(*p)["name"] = name
+	}
+
+	return p
+}
+
+func (p *UpdateReminderParams) WithLocation(lat string, long string) *UpdateReminderParams {
+	if lat != "" && long != "" {
+		(*p)["loc_lat"] = lat
+		(*p)["loc_long"] = long
+	}
+
+	return p
+}
+
+func (p *UpdateReminderParams) WithLocTrigger(locTrigger string) *UpdateReminderParams {
+	if locTrigger != "" {
+		(*p)["loc_trigger"] = locTrigger
+	}
+
+	return p
+}
+
+func (p *UpdateReminderParams) WithRadius(radius int) *UpdateReminderParams {
+	if radius != 0 {
+		(*p)["radius"] = radius
+	}
+
+	return p
+}
+
+func (t *Todoist) UpdateReminder(ctx context.Context, reminderId int, params *UpdateReminderParams) (err error) {
+	args := make(map[string]interface{}, len(*params)+1)
+	for key, value := range *params {
+		args[key] = value
+	}
+	args["id"] = reminderId
+
+	_, err = t.Sync(WithOperation(ctx, "UpdateReminder"), MakeSyncCommand("reminder_update", args))
+
+	return
+}
+
+// endregion
+
+// region DeleteReminder
+
+func (t *Todoist) DeleteReminder(ctx context.Context, reminderId int) (err error) {
+	_, err = t.Sync(WithOperation(ctx, "DeleteReminder"), MakeSyncCommand("reminder_delete", map[string]interface{}{"id": reminderId}))
+
+	return
+}
+
+// endregion
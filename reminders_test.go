@@ -0,0 +1,135 @@
+package todoist
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestGetReminders(t *testing.T) {
+	stub := newAPIStub(t)
+	stub.resources["reminders"] = []map[string]interface{}{
+		{"id": 1, "item_id": 10, "type": "relative", "mm_offset": 30},
+		{"id": 2, "item_id": 10, "type": "absolute", "due": map[string]interface{}{"date": "2024-05-01T09:00:00Z"}},
+		{"id": 3, "item_id": 11, "type": "relative"},
+		{"id": 4, "item_id": 10, "type": "relative", "is_deleted": 1},
+	}
+	client := stub.client()
+
+	tests := []struct {
+		name    string
+		params  *GetRemindersParams
+		wantIds []int
+	}{
+		{"all", MakeGetRemindersParams(), []int{1, 2, 3}},
+		{"task", MakeGetRemindersParams().WithTaskId(10), []int{1, 2}},
+		{"type", MakeGetRemindersParams().WithType(ReminderRelative), []int{1, 3}},
+		{"task and type", MakeGetRemindersParams().WithTaskId(10).WithType(ReminderAbsolute), []int{2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reminders, err := client.GetReminders(context.Background(), test.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := make([]int, len(reminders))
+			for i, reminder := range reminders {
+				ids[i] = reminder.Id
+			}
+			if !reflect.DeepEqual(ids, test.wantIds) {
+				t.Errorf("got %v, want %v", ids, test.wantIds)
+			}
+		})
+	}
+}
+
+func TestAddReminder(t *testing.T) {
+	stub := newAPIStub(t)
+	stub.resources["reminders"] = []map[string]interface{}{
+		{"id": 1, "item_id": 10, "type": "relative", "mm_offset": 15},
+		{
+			"id": 101, "item_id": 10, "notify_uid": 5, "type": "relative", "service": "push", "mm_offset": 30,
+			"due": map[string]interface{}{"date": "2024-05-01T08:30:00Z", "timezone": "Europe/Berlin"},
+		},
+	}
+
+	reminder, err := stub.client().AddReminder(context.Background(), MakeAddReminderParams().WithTaskId(10).WithType(ReminderRelative).WithMinuteOffset(30))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Reminder{
+		Id: 101, TaskId: 10, NotifyUid: 5, Type: ReminderRelative, Service: "push", MinuteOffset: 30,
+		Due: Due{Date: "2024-05-01T08:30:00Z", Timezone: "Europe/Berlin"},
+	}
+	if !reflect.DeepEqual(reminder, want) {
+		t.Errorf("got %+v, want %+v", reminder, want)
+	}
+
+	commands := stub.syncCommands()
+	wantArgs := map[string]interface{}{"item_id": 10.0, "type": "relative", "mm_offset": 30.0}
+	if len(commands) != 1 || commands[0].Type != "reminder_add" || !reflect.DeepEqual(commands[0].Args, wantArgs) {
+		t.Errorf("got commands %+v, want a reminder_add with %v", commands, wantArgs)
+	}
+}
+
+func TestAddReminderMissing(t *testing.T) {
+	stub := newAPIStub(t)
+
+	if _, err := stub.client().AddReminder(context.Background(), MakeAddReminderParams().WithTaskId(10)); err == nil {
+		t.Error("got no error for a reminder missing from the read")
+	}
+}
+
+func TestReminderCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		run      func(ctx context.Context, client *Todoist) error
+		wantType string
+		wantArgs map[string]interface{}
+	}{
+		{
+			name: "update",
+			run: func(ctx context.Context, client *Todoist) error {
+				return client.UpdateReminder(ctx, 7, MakeUpdateReminderParams().WithDueString("tomorrow 9am").WithMinuteOffset(0))
+			},
+			wantType: "reminder_update",
+			wantArgs: map[string]interface{}{"id": 7.0, "due": map[string]interface{}{"string": "tomorrow 9am"}, "mm_offset": 0.0},
+		},
+		{
+			name: "location",
+			run: func(ctx context.Context, client *Todoist) error {
+				return client.UpdateReminder(ctx, 7, MakeUpdateReminderParams().WithLocation("52.52", "13.40").WithLocTrigger(ReminderOnLeave).WithRadius(100))
+			},
+			wantType: "reminder_update",
+			wantArgs: map[string]interface{}{"id": 7.0, "loc_lat": "52.52", "loc_long": "13.40", "loc_trigger": "on_leave", "radius": 100.0},
+		},
+		{
+			name: "delete",
+			run: func(ctx context.Context, client *Todoist) error {
+				return client.DeleteReminder(ctx, 7)
+			},
+			wantType: "reminder_delete",
+			wantArgs: map[string]interface{}{"id": 7.0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stub := newAPIStub(t)
+			if err := test.run(context.Background(), stub.client()); err != nil {
+				t.Fatal(err)
+			}
+
+			commands := stub.syncCommands()
+			if len(commands) != 1 {
+				t.Fatalf("got %d commands, want 1", len(commands))
+			}
+			if commands[0].Type != test.wantType || !reflect.DeepEqual(commands[0].Args, test.wantArgs) {
+				t.Errorf("got %s %v, want %s %v", commands[0].Type, commands[0].Args, test.wantType, test.wantArgs)
+			}
+		})
+	}
+}
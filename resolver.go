@@ -0,0 +1,335 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+const DefaultResolverTTL = time.Minute
+
+var ErrNotFound = errors.New("not found")
+var ErrAmbiguous = errors.New("ambiguous name")
+
+type Resolver struct {
+	todoist Client
+	ttl     time.Duration
+
+	mutex   sync.Mutex
+	entries map[string]*resolverEntry
+}
+
+// resolverEntry is a cached list. The lock is not held while it is fetched,
+// callers asking for it meanwhile wait for the same fetch.
+type resolverEntry struct {
+	expires time.Time
+	items   interface{}
+	err     error
+	done    bool
+	loaded  chan struct{}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func NewResolver(todoist Client, ttl time.Duration) *Resolver {
+	if ttl == 0 {
+		ttl = DefaultResolverTTL
+	}
+
+	return &Resolver{
+		todoist: todoist,
+		ttl:     ttl,
+		entries: make(map[string]*resolverEntry),
+	}
+}
+
+func (r *Resolver) Invalidate() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.entries = make(map[string]*resolverEntry)
+}
+
+// region Projects
+
+func (r *Resolver) Projects(ctx context.Context) (projects []Project, err error) {
+	var items interface{}
+	if items, err = r.load(ctx, "projects", func() (interface{}, error) { return r.todoist.GetProjects(ctx) }); err != nil {
+		return
+	}
+
+	return append([]Project(nil), items.([]Project)...), nil
+}
+
+func (r *Resolver) ProjectByName(ctx context.Context, name string) (project *Project, err error) {
+	var projects []Project
+	if projects, err = r.Projects(ctx); err != nil {
+		return
+	}
+
+	var index int
+	if index, err = matchName(len(projects), func(i int) string { return projects[i].Name }, name, "project"); err != nil {
+		return
+	}
+
+	project = new(Project)
+	*project = projects[index]
+
+	return
+}
+
+func (r *Resolver) GetOrCreateProject(ctx context.Context, name string) (project *Project, err error) {
+	if project, err = r.ProjectByName(ctx, name); !errors.Is(err, ErrNotFound) {
+		return
+	}
+
+	if project, err = r.todoist.AddProject(ctx, MakeAddProjectParams().WithName(name)); err != nil {
+		return
+	}
+
+	r.update("projects", func(items interface{}) interface{} {
+		return append(append(make([]Project, 0, len(items.([]Project))+1), items.([]Project)...), *project)
+	})
+
+	return
+}
+
+// endregion
+
+// region Sections
+
+func (r *Resolver) Sections(ctx context.Context, projectId int) (sections []Section, err error) {
+	var items interface{}
+	if items, err = r.load(ctx, sectionsKey(projectId), func() (interface{}, error) {
+		return r.todoist.GetSections(ctx, MakeGetSectionsParams().WithProjectId(projectId))
+	}); err != nil {
+		return
+	}
+
+	return append([]Section(nil), items.([]Section)...), nil
+}
+
+func (r *Resolver) SectionByName(ctx context.Context, projectId int, name string) (section *Section, err error) {
+	var sections []Section
+	if sections, err = r.Sections(ctx, projectId); err != nil {
+		return
+	}
+
+	var index int
+	if index, err = matchName(len(sections), func(i int) string { return sections[i].Name }, name, "section"); err != nil {
+		return
+	}
+
+	section = new(Section)
+	*section = sections[index]
+
+	return
+}
+
+func (r *Resolver) GetOrCreateSection(ctx context.Context, projectId int, name string) (section *Section, err error) {
+	if section, err = r.SectionByName(ctx, projectId, name); !errors.Is(err, ErrNotFound) {
+		return
+	}
+
+	if section, err = r.todoist.AddSection(ctx, MakeAddSectionParams().WithProjectId(projectId).WithName(name)); err != nil {
+		return
+	}
+
+	r.update(sectionsKey(projectId), func(items interface{}) interface{} {
+		return append(append(make([]Section, 0, len(items.([]Section))+1), items.([]Section)...), *section)
+	})
+
+	return
+}
+
+// endregion
+
+// region Labels
+
+func (r *Resolver) Labels(ctx context.Context) (labels []Label, err error) {
+	var items interface{}
+	if items, err = r.load(ctx, "labels", func() (interface{}, error) { return r.todoist.GetLabels(ctx) }); err != nil {
+		return
+	}
+
+	return append([]Label(nil), items.([]Label)...), nil
+}
+
+func (r *Resolver) LabelByName(ctx context.Context, name string) (label *Label, err error) {
+	var labels []Label
+	if labels, err = r.Labels(ctx); err != nil {
+		return
+	}
+
+	name = strings.TrimPrefix(strings.TrimSpace(name), "@")
+
+	var index int
+	if index, err = matchName(len(labels), func(i int) string { return labels[i].Name }, name, "label"); err != nil {
+		return
+	}
+
+	label = new(Label)
+	*label = labels[index]
+
+	return
+}
+
+func (r *Resolver) GetOrCreateLabel(ctx context.Context, name string) (label *Label, err error) {
+	if label, err = r.LabelByName(ctx, name); !errors.Is(err, ErrNotFound) {
+		return
+	}
+
+	name = strings.TrimPrefix(strings.TrimSpace(name), "@")
+	if label, err = r.todoist.AddLabel(ctx, MakeAddLabelParams().WithName(name)); err != nil {
+		return
+	}
+
+	r.update("labels", func(items interface{}) interface{} {
+		return append(append(make([]Label, 0, len(items.([]Label))+1), items.([]Label)...), *label)
+	})
+
+	return
+}
+
+// endregion
+
+// load returns the cached items under key, calling fetch when they are missing
+// or expired. Failed fetches are not cached.
+func (r *Resolver) load(ctx context.Context, key string, fetch func() (interface{}, error)) (items interface{}, err error) {
+	r.mutex.Lock()
+	entry := r.entries[key]
+	if entry != nil && !entry.done {
+		r.mutex.Unlock()
+
+		select {
+		case <-entry.loaded:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		return entry.items, entry.err
+	}
+
+	if entry.valid() {
+		r.mutex.Unlock()
+		return entry.items, nil
+	}
+
+	entry = &resolverEntry{loaded: make(chan struct{})}
+	r.entries[key] = entry
+	r.mutex.Unlock()
+
+	items, err = fetch()
+
+	r.mutex.Lock()
+	entry.items, entry.err, entry.done = items, err, true
+	entry.expires = time.Now().Add(r.ttl)
+	if err != nil && r.entries[key] == entry {
+		delete(r.entries, key)
+	}
+	r.mutex.Unlock()
+	close(entry.loaded)
+
+	return
+}
+
+// update replaces the cached items under key, if they are still valid.
+// The cached slices are shared with earlier callers, so they are never changed in place.
+func (r *Resolver) update(key string, fn func(items interface{}) interface{}) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if entry := r.entries[key]; entry.valid() {
+		entry.items = fn(entry.items)
+	}
+}
+
+func (e *resolverEntry) valid() bool {
+	return e != nil && e.done && e.err == nil && time.Now().Before(e.expires)
+}
+
+func sectionsKey(projectId int) string {
+	return "sections:" + strconv.Itoa(projectId)
+}
+
+// Exact matches win over folded ones, so "Inbox" and "inbox" can coexist
+// as long as the caller spells the name exactly.
+func matchName(count int, nameOf func(i int) string, name string, kind string) (index int, err error) {
+	name = strings.TrimSpace(name)
+	folded := FoldName(name)
+
+	exact := make([]int, 0, 1)
+	similar := make([]int, 0, 1)
+	for i := 0; i < count; i++ {
+		candidate := nameOf(i)
+		if candidate == name {
+			exact = append(exact, i)
+		} else if FoldName(candidate) == folded {
+			similar = append(similar, i)
+		}
+	}
+
+	matches := exact
+	if len(matches) == 0 {
+		matches = similar
+	}
+
+	switch len(matches) {
+	case 0:
+		return -1, fmt.Errorf("%s %q: %w", kind, name, ErrNotFound)
+	case 1:
+		return matches[0], nil
+	default:
+		return -1, fmt.Errorf("%s %q matches %d objects: %w", kind, name, len(matches), ErrAmbiguous)
+	}
+}
+
+func FoldName(name string) string {
+	folded := strings.Builder{}
+	for _, r := range strings.TrimSpace(name) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+
+		if base, ok := diacritics[r]; ok {
+			folded.WriteString(base)
+		} else {
+			folded.WriteRune(unicode.ToLower(r))
+		}
+	}
+
+	return folded.String()
+}
+
+var diacritics = map[rune]string{
+	'À': "a", 'Á': "a", 'Â': "a", 'Ã': "a", 'Ä': "a", 'Å': "a", 'Ā': "a", 'Ă': "a", 'Ą': "a",
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a", 'ă': "a", 'ą': "a",
+	'Æ': "ae", 'æ': "ae",
+	'Ç': "c", 'Ć': "c", 'Č': "c", 'ç': "c", 'ć': "c", 'č': "c",
+	'Ď': "d", 'Đ': "d", 'ď': "d", 'đ': "d",
+	'È': "e", 'É': "e", 'Ê': "e", 'Ë': "e", 'Ē': "e", 'Ė': "e", 'Ę': "e", 'Ě': "e",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ė': "e", 'ę': "e", 'ě': "e",
+	'Ğ': "g", 'ğ': "g",
+	'Ì': "i", 'Í': "i", 'Î': "i", 'Ï': "i", 'Ī': "i", 'İ': "i",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ī': "i", 'ı': "i",
+	'Ł': "l", 'ł': "l",
+	'Ñ': "n", 'Ń': "n", 'Ň': "n", 'ñ': "n", 'ń': "n", 'ň': "n",
+	'Ò': "o", 'Ó': "o", 'Ô': "o", 'Õ': "o", 'Ö': "o", 'Ø': "o", 'Ō': "o", 'Ő': "o",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o", 'ő': "o",
+	'Œ': "oe", 'œ': "oe",
+	'Ř': "r", 'ř': "r",
+	'Ś': "s", 'Š': "s", 'Ş': "s", 'ś': "s", 'š': "s", 'ş': "s", 'ß': "ss",
+	'Ť': "t", 'ť': "t",
+	'Ù': "u", 'Ú': "u", 'Û': "u", 'Ü': "u", 'Ū': "u", 'Ů': "u", 'Ű': "u",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ū': "u", 'ů': "u", 'ű': "u",
+	'Ý': "y", 'Ÿ': "y", 'ý': "y", 'ÿ': "y",
+	'Ź': "z", 'Ż': "z", 'Ž': "z", 'ź': "z", 'ż': "z", 'ž': "z",
+	'Ё': "е", 'ё': "е", 'Й': "и", 'й': "и",
+}
@@ -0,0 +1,173 @@
+package todoist_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/temoon/todoist-api"
+	"github.com/temoon/todoist-api/todoistmock"
+)
+
+func TestResolverMatchesNames(t *testing.T) {
+	client := &todoistmock.Client{
+		GetProjectsFunc: func(ctx context.Context) ([]todoist.Project, error) {
+			return []todoist.Project{{Id: 1, Name: "Inbox"}, {Id: 2, Name: "inbox"}, {Id: 3, Name: "Café"}, {Id: 4, Name: "Work"}, {Id: 5, Name: "work"}}, nil
+		},
+	}
+	resolver := todoist.NewResolver(client, 0)
+
+	tests := []struct {
+		name    string
+		wantId  int
+		wantErr error
+	}{
+		{"Inbox", 1, nil},
+		{"inbox", 2, nil},
+		{"cafe", 3, nil},
+		{" CAFÉ ", 3, nil},
+		{"WORK", 0, todoist.ErrAmbiguous},
+		{"Home", 0, todoist.ErrNotFound},
+	}
+
+	for _, test := range tests {
+		project, err := resolver.ProjectByName(context.Background(), test.name)
+		if test.wantErr != nil {
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("ProjectByName(%q): got %v, want %v", test.name, err, test.wantErr)
+			}
+			continue
+		}
+
+		if err != nil || project.Id != test.wantId {
+			t.Errorf("ProjectByName(%q): got %+v, %v, want id %d", test.name, project, err, test.wantId)
+		}
+	}
+
+	if calls := len(client.CallsTo("GetProjects")); calls != 1 {
+		t.Errorf("got %d GetProjects calls, want 1 cached", calls)
+	}
+}
+
+func TestResolverCacheExpiresAndInvalidates(t *testing.T) {
+	client := &todoistmock.Client{
+		GetLabelsFunc: func(ctx context.Context) ([]todoist.Label, error) {
+			return []todoist.Label{{Id: 1, Name: "home"}}, nil
+		},
+	}
+	resolver := todoist.NewResolver(client, 20*time.Millisecond)
+	ctx := context.Background()
+
+	_, _ = resolver.Labels(ctx)
+	_, _ = resolver.Labels(ctx)
+	time.Sleep(30 * time.Millisecond)
+	_, _ = resolver.Labels(ctx)
+	resolver.Invalidate()
+	_, _ = resolver.Labels(ctx)
+
+	if calls := len(client.CallsTo("GetLabels")); calls != 3 {
+		t.Errorf("got %d GetLabels calls, want 3", calls)
+	}
+}
+
+func TestResolverDoesNotCacheErrors(t *testing.T) {
+	failures := 1
+	client := &todoistmock.Client{
+		GetLabelsFunc: func(ctx context.Context) ([]todoist.Label, error) {
+			if failures > 0 {
+				failures--
+				return nil, errors.New("unavailable")
+			}
+			return []todoist.Label{{Id: 1, Name: "home"}}, nil
+		},
+	}
+	resolver := todoist.NewResolver(client, 0)
+
+	if _, err := resolver.LabelByName(context.Background(), "home"); err == nil {
+		t.Fatal("first lookup: expected an error")
+	}
+	if label, err := resolver.LabelByName(context.Background(), "@home"); err != nil || label.Id != 1 {
+		t.Errorf("second lookup: got %+v, %v", label, err)
+	}
+}
+
+func TestResolverGetOrCreateCopiesCache(t *testing.T) {
+	nextId := 10
+	client := &todoistmock.Client{
+		GetSectionsFunc: func(ctx context.Context, params *todoist.GetSectionsParams) ([]todoist.Section, error) {
+			sections := make([]todoist.Section, 1, 4)
+			sections[0] = todoist.Section{Id: 1, ProjectId: 5, Name: "Todo"}
+			return sections, nil
+		},
+		AddSectionFunc: func(ctx context.Context, params *todoist.AddSectionParams) (*todoist.Section, error) {
+			nextId++
+			return &todoist.Section{Id: nextId, ProjectId: 5, Name: (*params)["name"].(string)}, nil
+		},
+	}
+	resolver := todoist.NewResolver(client, 0)
+	ctx := context.Background()
+
+	before, _ := resolver.Sections(ctx, 5)
+	if _, err := resolver.GetOrCreateSection(ctx, 5, "Done"); err != nil {
+		t.Fatalf("GetOrCreateSection: %s", err)
+	}
+	before = append(before, todoist.Section{Id: 99, Name: "Caller"})
+
+	section, err := resolver.GetOrCreateSection(ctx, 5, "done")
+	if err != nil || section.Id != 11 {
+		t.Errorf("GetOrCreateSection: got %+v, %v, want the cached section 11", section, err)
+	}
+
+	after, _ := resolver.Sections(ctx, 5)
+	if len(after) != 2 || after[1].Name != "Done" {
+		t.Errorf("cache changed by a caller: got %+v", after)
+	}
+	if calls := len(client.CallsTo("AddSection")); calls != 1 {
+		t.Errorf("got %d AddSection calls, want 1", calls)
+	}
+}
+
+func TestResolverFetchesOnceConcurrently(t *testing.T) {
+	release := make(chan struct{})
+	client := &todoistmock.Client{
+		GetSectionsFunc: func(ctx context.Context, params *todoist.GetSectionsParams) ([]todoist.Section, error) {
+			if (*params)["project_id"] == "1" {
+				<-release
+			}
+			return []todoist.Section{}, nil
+		},
+	}
+	resolver := todoist.NewResolver(client, 0)
+	ctx := context.Background()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = resolver.Sections(ctx, 1)
+		}()
+	}
+
+	// The slow project must not block lookups for another one.
+	done := make(chan struct{})
+	go func() {
+		_, _ = resolver.Sections(ctx, 2)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("lookup of project 2 waited for project 1")
+	}
+
+	close(release)
+	wg.Wait()
+
+	if calls := len(client.CallsTo("GetSections")); calls != 2 {
+		t.Errorf("got %d GetSections calls, want one per project", calls)
+	}
+}
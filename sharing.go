@@ -0,0 +1,205 @@
+package todoist
+
+import (
+	"context"
+	"strings"
+)
+
+const (
+	InvitationInvited  = "invited"
+	InvitationAccepted = "accepted"
+	InvitationRejected = "rejected"
+)
+
+type Invitation struct {
+	Id               int              `json:"id"`
+	InvitationId     int              `json:"invitation_id"`
+	InvitationSecret string           `json:"invitation_secret"`
+	ProjectId        int              `json:"project_id"`
+	ProjectName      string           `json:"project_name"`
+	FromUser         InvitationSender `json:"from_user"`
+	State            string           `json:"state"`
+	CreatedDate      string           `json:"created_date"`
+}
+
+type InvitationSender struct {
+	Id       int    `json:"id"`
+	Email    string `json:"email"`
+	FullName string `json:"full_name"`
+}
+
+type MirrorCollaboratorsResult struct {
+	Shared  map[int][]string
+	Removed map[int][]string
+}
+
+// region ShareProject
+
+// ShareProject invites the email to the project. People without a Todoist
+// account get an email asking them to sign up.
+func (t *Todoist) ShareProject(ctx context.Context, projectId int, email string) (err error) {
+	_, err = t.Sync(WithOperation(ctx, "ShareProject"), makeShareCommand(projectId, email))
+
+	return
+}
+
+func makeShareCommand(projectId int, email string) SyncCommand {
+	return MakeSyncCommand("share_project", map[string]interface{}{"project_id": projectId, "email": email})
+}
+
+// endregion
+
+// region DeleteCollaborator
+
+func (t *Todoist) DeleteCollaborator(ctx context.Context, projectId int, email string) (err error) {
+	_, err = t.Sync(WithOperation(ctx, "DeleteCollaborator"), makeDeleteCollaboratorCommand(projectId, email))
+
+	return
+}
+
+func makeDeleteCollaboratorCommand(projectId int, email string) SyncCommand {
+	return MakeSyncCommand("delete_collaborator", map[string]interface{}{"project_id": projectId, "email": email})
+}
+
+// endregion
+
+// region GetInvitations
+
+// GetInvitations returns the project invitations sent to the user that are
+// still waiting for an answer.
+func (t *Todoist) GetInvitations(ctx context.Context) (invitations []Invitation, err error) {
+	res := &struct {
+		LiveNotifications []struct {
+			Invitation
+			NotificationType string   `json:"notification_type"`
+			IsDeleted        syncBool `json:"is_deleted"`
+		} `json:"live_notifications"`
+	}{}
+
+	if err = t.syncRead(WithOperation(ctx, "GetInvitations"), []string{"live_notifications"}, res); err != nil {
+		return
+	}
+
+	invitations = make([]Invitation, 0)
+	for _, notification := range res.LiveNotifications {
+		if notification.NotificationType != "share_invitation_sent" || notification.IsDeleted || notification.State != InvitationInvited {
+			continue
+		}
+
+		invitations = append(invitations, notification.Invitation)
+	}
+
+	return
+}
+
+// endregion
+
+// region AcceptInvitation
+
+func (t *Todoist) AcceptInvitation(ctx context.Context, invitation *Invitation) (err error) {
+	_, err = t.Sync(WithOperation(ctx, "AcceptInvitation"), makeInvitationCommand("accept_invitation", invitation))
+
+	return
+}
+
+// endregion
+
+// region RejectInvitation
+
+func (t *Todoist) RejectInvitation(ctx context.Context, invitation *Invitation) (err error) {
+	_, err = t.Sync(WithOperation(ctx, "RejectInvitation"), makeInvitationCommand("reject_invitation", invitation))
+
+	return
+}
+
+func makeInvitationCommand(commandType string, invitation *Invitation) SyncCommand {
+	return MakeSyncCommand(commandType, map[string]interface{}{
+		"invitation_id":     invitation.InvitationId,
+		"invitation_secret": invitation.InvitationSecret,
+	})
+}
+
+// endregion
+
+// region MirrorCollaborators
+
+// MirrorCollaborators shares every target project with the collaborators of
+// the source one. With prune, collaborators missing from the source are
+// removed from the targets. The user is never shared with or removed. Emails
+// are compared without case. Invitations that are not accepted yet are sent
+// again on the next run. The result lists the changes of the targets that were
+// updated before an error.
+func (t *Todoist) MirrorCollaborators(ctx context.Context, sourceProjectId int, targetProjectIds []int, prune bool) (result *MirrorCollaboratorsResult, err error) {
+	result = &MirrorCollaboratorsResult{
+		Shared:  make(map[int][]string),
+		Removed: make(map[int][]string),
+	}
+
+	var source []Collaborator
+	if source, err = t.GetCollaborators(ctx, sourceProjectId); err != nil {
+		return
+	}
+
+	var user *User
+	if user, err = t.GetUser(ctx); err != nil {
+		return
+	}
+	self := strings.ToLower(user.Email)
+
+	emails := make(map[string]bool, len(source))
+	for _, collaborator := range source {
+		emails[strings.ToLower(collaborator.Email)] = true
+	}
+
+	for _, projectId := range targetProjectIds {
+		if projectId == sourceProjectId {
+			continue
+		}
+
+		var target []Collaborator
+		if target, err = t.GetCollaborators(ctx, projectId); err != nil {
+			return
+		}
+
+		current := make(map[string]bool, len(target))
+		commands := make([]SyncCommand, 0)
+		shared, removed := make([]string, 0), make([]string, 0)
+
+		for _, collaborator := range target {
+			email := strings.ToLower(collaborator.Email)
+			current[email] = true
+
+			if prune && !emails[email] && email != self {
+				commands = append(commands, makeDeleteCollaboratorCommand(projectId, collaborator.Email))
+				removed = append(removed, collaborator.Email)
+			}
+		}
+
+		for _, collaborator := range source {
+			email := strings.ToLower(collaborator.Email)
+			if !current[email] && email != self {
+				commands = append(commands, makeShareCommand(projectId, collaborator.Email))
+				shared = append(shared, collaborator.Email)
+			}
+		}
+
+		if len(commands) == 0 {
+			continue
+		}
+
+		if _, err = t.Sync(WithOperation(ctx, "MirrorCollaborators"), commands...); err != nil {
+			return
+		}
+
+		if len(shared) != 0 {
+			result.Shared[projectId] = shared
+		}
+		if len(removed) != 0 {
+			result.Removed[projectId] = removed
+		}
+	}
+
+	return
+}
+
+// endregion
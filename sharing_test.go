@@ -0,0 +1,150 @@
+package todoist
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestGetInvitations(t *testing.T) {
+	stub := newAPIStub(t)
+	stub.resources["live_notifications"] = []map[string]interface{}{
+		{"id": 1, "notification_type": "share_invitation_sent", "state": "invited", "invitation_id": 11, "invitation_secret": "a", "project_name": "Work"},
+		{"id": 2, "notification_type": "share_invitation_sent", "state": "accepted", "invitation_id": 12},
+		{"id": 3, "notification_type": "share_invitation_sent", "state": "invited", "invitation_id": 13, "is_deleted": 1},
+		{"id": 4, "notification_type": "item_assigned", "state": "invited"},
+	}
+
+	invitations, err := stub.client().GetInvitations(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Invitation{{Id: 1, InvitationId: 11, InvitationSecret: "a", ProjectName: "Work", State: InvitationInvited}}
+	if !reflect.DeepEqual(invitations, want) {
+		t.Errorf("got %+v, want %+v", invitations, want)
+	}
+}
+
+func TestSharingCommands(t *testing.T) {
+	invitation := &Invitation{InvitationId: 11, InvitationSecret: "a"}
+
+	tests := []struct {
+		name     string
+		run      func(ctx context.Context, client *Todoist) error
+		wantType string
+		wantArgs map[string]interface{}
+	}{
+		{
+			name: "share",
+			run: func(ctx context.Context, client *Todoist) error {
+				return client.ShareProject(ctx, 1, "bob@example.com")
+			},
+			wantType: "share_project",
+			wantArgs: map[string]interface{}{"project_id": 1.0, "email": "bob@example.com"},
+		},
+		{
+			name: "delete collaborator",
+			run: func(ctx context.Context, client *Todoist) error {
+				return client.DeleteCollaborator(ctx, 1, "bob@example.com")
+			},
+			wantType: "delete_collaborator",
+			wantArgs: map[string]interface{}{"project_id": 1.0, "email": "bob@example.com"},
+		},
+		{
+			name: "accept",
+			run: func(ctx context.Context, client *Todoist) error {
+				return client.AcceptInvitation(ctx, invitation)
+			},
+			wantType: "accept_invitation",
+			wantArgs: map[string]interface{}{"invitation_id": 11.0, "invitation_secret": "a"},
+		},
+		{
+			name: "reject",
+			run: func(ctx context.Context, client *Todoist) error {
+				return client.RejectInvitation(ctx, invitation)
+			},
+			wantType: "reject_invitation",
+			wantArgs: map[string]interface{}{"invitation_id": 11.0, "invitation_secret": "a"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stub := newAPIStub(t)
+			if err := test.run(context.Background(), stub.client()); err != nil {
+				t.Fatal(err)
+			}
+
+			commands := stub.syncCommands()
+			if len(commands) != 1 {
+				t.Fatalf("got %d commands, want 1", len(commands))
+			}
+			if commands[0].Type != test.wantType || !reflect.DeepEqual(commands[0].Args, test.wantArgs) {
+				t.Errorf("got %s %v, want %s %v", commands[0].Type, commands[0].Args, test.wantType, test.wantArgs)
+			}
+		})
+	}
+}
+
+func TestMirrorCollaborators(t *testing.T) {
+	tests := []struct {
+		name        string
+		prune       bool
+		wantShared  map[int][]string
+		wantRemoved map[int][]string
+		wantTypes   []string
+	}{
+		{
+			name:        "share",
+			wantShared:  map[int][]string{2: {"bob@example.com"}, 3: {"Ann@example.com", "bob@example.com"}},
+			wantRemoved: map[int][]string{},
+			wantTypes:   []string{"share_project", "share_project", "share_project"},
+		},
+		{
+			name:        "prune",
+			prune:       true,
+			wantShared:  map[int][]string{2: {"bob@example.com"}, 3: {"Ann@example.com", "bob@example.com"}},
+			wantRemoved: map[int][]string{2: {"carol@example.com"}},
+			wantTypes:   []string{"delete_collaborator", "share_project", "share_project", "share_project"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stub := newAPIStub(t)
+			stub.resources["user"] = map[string]interface{}{"id": 8, "email": "me@example.com"}
+			stub.responses["/rest/v1/projects/1/collaborators"] = []map[string]interface{}{
+				{"id": 8, "email": "me@example.com"},
+				{"id": 7, "email": "Ann@example.com"},
+				{"id": 9, "email": "bob@example.com"},
+			}
+			stub.responses["/rest/v1/projects/2/collaborators"] = []map[string]interface{}{
+				{"id": 8, "email": "ME@example.com"},
+				{"id": 7, "email": "ann@example.com"},
+				{"id": 10, "email": "carol@example.com"},
+			}
+			stub.responses["/rest/v1/projects/3/collaborators"] = []map[string]interface{}{}
+
+			result, err := stub.client().MirrorCollaborators(context.Background(), 1, []int{1, 2, 3}, test.prune)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(result.Shared, test.wantShared) {
+				t.Errorf("shared: got %v, want %v", result.Shared, test.wantShared)
+			}
+			if !reflect.DeepEqual(result.Removed, test.wantRemoved) {
+				t.Errorf("removed: got %v, want %v", result.Removed, test.wantRemoved)
+			}
+
+			types := make([]string, 0)
+			for _, command := range stub.syncCommands() {
+				types = append(types, command.Type)
+			}
+			if !reflect.DeepEqual(types, test.wantTypes) {
+				t.Errorf("commands: got %v, want %v", types, test.wantTypes)
+			}
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const ProductivityStatsEndpoint = "completed/get_stats"
+
+const DefaultProductivityDays = 7
+const DefaultProductivityWeeks = 4
+const DefaultDailyGoal = 5
+const DefaultWeeklyGoal = 25
+
+const productivityDateLayout = "2006-01-02"
+
+type ProductivityStats struct {
+	Karma              float64              `json:"karma"`
+	KarmaTrend         string               `json:"karma_trend"`
+	KarmaLastUpdate    float64              `json:"karma_last_update"`
+	KarmaUpdateReasons []KarmaUpdate        `json:"karma_update_reasons"`
+	CompletedCount     int                  `json:"completed_count"`
+	DaysItems          []ProductivityPeriod `json:"days_items"`
+	WeekItems          []ProductivityWeek   `json:"week_items"`
+	Goals              ProductivityGoals    `json:"goals"`
+}
+
+type KarmaUpdate struct {
+	Time                 string  `json:"time"`
+	NewKarma             float64 `json:"new_karma"`
+	PositiveKarma        float64 `json:"positive_karma"`
+	NegativeKarma        float64 `json:"negative_karma"`
+	PositiveKarmaReasons []int   `json:"positive_karma_reasons"`
+	NegativeKarmaReasons []int   `json:"negative_karma_reasons"`
+}
+
+// ProductivityPeriod is a day of the days_items breakdown.
+type ProductivityPeriod struct {
+	Date           string                  `json:"date"`
+	TotalCompleted int                     `json:"total_completed"`
+	Items          []ProjectCompletedCount `json:"items"`
+}
+
+// ProductivityWeek is a week of the week_items breakdown, from Monday to Sunday.
+type ProductivityWeek struct {
+	From           string                  `json:"from"`
+	To             string                  `json:"to"`
+	TotalCompleted int                     `json:"total_completed"`
+	Items          []ProjectCompletedCount `json:"items"`
+}
+
+type ProjectCompletedCount struct {
+	ProjectId int `json:"id"`
+	Completed int `json:"completed"`
+}
+
+type ProductivityGoals struct {
+	DailyGoal           int      `json:"daily_goal"`
+	WeeklyGoal          int      `json:"weekly_goal"`
+	IgnoreDays          []int    `json:"ignore_days"`
+	VacationMode        syncBool `json:"vacation_mode"`
+	KarmaDisabled       syncBool `json:"karma_disabled"`
+	CurrentDailyStreak  Streak   `json:"current_daily_streak"`
+	MaxDailyStreak      Streak   `json:"max_daily_streak"`
+	CurrentWeeklyStreak Streak   `json:"current_weekly_streak"`
+	MaxWeeklyStreak     Streak   `json:"max_weekly_streak"`
+}
+
+type Streak struct {
+	Count int    `json:"count"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// The stats endpoint has returned project ids both as numbers and as strings.
+func (c *ProjectCompletedCount) UnmarshalJSON(data []byte) (err error) {
+	value := struct {
+		Id        json.RawMessage `json:"id"`
+		Completed int             `json:"completed"`
+	}{}
+
+	if err = json.Unmarshal(data, &value); err != nil {
+		return
+	}
+
+	c.Completed = value.Completed
+	if len(value.Id) != 0 && string(value.Id) != "null" {
+		c.ProjectId, err = strconv.Atoi(strings.Trim(string(value.Id), `"`))
+	}
+
+	return
+}
+
+// region GetProductivityStats
+
+func (t *Todoist) GetProductivityStats(ctx context.Context) (stats *ProductivityStats, err error) {
+	stats = new(ProductivityStats)
+	err = t.requestUrl(ctx, http.MethodGet, SyncBaseUrl, ProductivityStatsEndpoint, nil, nil, stats)
+
+	return
+}
+
+// endregion
+
+// region AggregateProductivityStats
+
+type ProductivityOpts struct {
+	Days     int
+	Weeks    int
+	Goals    ProductivityGoals
+	Location *time.Location
+	Now      time.Time
+}
+
+// AggregateProductivityStats computes the day and week breakdowns and the
+// streaks from completed tasks, for when the stats endpoint is unavailable.
+// Only the goals and ignored days of opts.Goals are used, karma is left empty
+// and streaks cannot reach further back than the given history.
+func AggregateProductivityStats(tasks []CompletedTask, opts *ProductivityOpts) *ProductivityStats {
+	opts = opts.withDefaults()
+
+	days := make(map[string]map[int]int)
+	for _, task := range tasks {
+		completed, err := time.Parse(time.RFC3339, task.CompletedDate)
+		if err != nil {
+			continue
+		}
+
+		day := completed.In(opts.Location).Format(productivityDateLayout)
+		if days[day] == nil {
+			days[day] = make(map[int]int)
+		}
+		days[day][task.ProjectId]++
+	}
+
+	today := startOfDay(opts.Now.In(opts.Location))
+	stats := &ProductivityStats{
+		KarmaUpdateReasons: make([]KarmaUpdate, 0),
+		CompletedCount:     len(tasks),
+		DaysItems:          make([]ProductivityPeriod, 0, opts.Days),
+		WeekItems:          make([]ProductivityWeek, 0, opts.Weeks),
+		Goals:              opts.Goals,
+	}
+
+	for i := 0; i < opts.Days; i++ {
+		stats.DaysItems = append(stats.DaysItems, productivityPeriod(days, today.AddDate(0, 0, -i), 1))
+	}
+
+	week := startOfWeek(today)
+	for i := 0; i < opts.Weeks; i++ {
+		start := week.AddDate(0, 0, -7*i)
+		period := productivityPeriod(days, start, 7)
+		stats.WeekItems = append(stats.WeekItems, ProductivityWeek{
+			From:           period.Date,
+			To:             start.AddDate(0, 0, 6).Format(productivityDateLayout),
+			TotalCompleted: period.TotalCompleted,
+			Items:          period.Items,
+		})
+	}
+
+	first := today
+	for day := range days {
+		if date, err := time.ParseInLocation(productivityDateLayout, day, opts.Location); err == nil && date.Before(first) {
+			first = date
+		}
+	}
+
+	ignored := make(map[time.Weekday]bool)
+	for _, day := range opts.Goals.IgnoreDays {
+		// The goals use ISO week days, from 1 for Monday to 7 for Sunday.
+		ignored[time.Weekday(day%7)] = true
+	}
+
+	stats.Goals.CurrentDailyStreak, stats.Goals.MaxDailyStreak = productivityStreaks(first, today, 1, func(date time.Time) (bool, bool) {
+		if ignored[date.Weekday()] {
+			return false, true
+		}
+
+		return productivityPeriod(days, date, 1).TotalCompleted >= opts.Goals.DailyGoal, false
+	})
+
+	stats.Goals.CurrentWeeklyStreak, stats.Goals.MaxWeeklyStreak = productivityStreaks(startOfWeek(first), week, 7, func(date time.Time) (bool, bool) {
+		return productivityPeriod(days, date, 7).TotalCompleted >= opts.Goals.WeeklyGoal, false
+	})
+
+	return stats
+}
+
+// ComputeProductivityStats aggregates the completed tasks since opts.Now
+// minus the longest of the requested days and weeks.
+func (t *Todoist) ComputeProductivityStats(ctx context.Context, opts *ProductivityOpts) (stats *ProductivityStats, err error) {
+	opts = opts.withDefaults()
+
+	today := startOfDay(opts.Now.In(opts.Location))
+	since := startOfWeek(today).AddDate(0, 0, -7*(opts.Weeks-1))
+	if days := today.AddDate(0, 0, 1-opts.Days); days.Before(since) {
+		since = days
+	}
+
+	var tasks []CompletedTask
+	if tasks, err = t.GetCompletedTasks(ctx, MakeGetCompletedTasksParams().WithSince(since)); err != nil {
+		return
+	}
+
+	return AggregateProductivityStats(tasks, opts), nil
+}
+
+func (o *ProductivityOpts) withDefaults() *ProductivityOpts {
+	opts := ProductivityOpts{}
+	if o != nil {
+		opts = *o
+	}
+
+	if opts.Days <= 0 {
+		opts.Days = DefaultProductivityDays
+	}
+
+	if opts.Weeks <= 0 {
+		opts.Weeks = DefaultProductivityWeeks
+	}
+
+	if opts.Goals.DailyGoal <= 0 {
+		opts.Goals.DailyGoal = DefaultDailyGoal
+	}
+
+	if opts.Goals.WeeklyGoal <= 0 {
+		opts.Goals.WeeklyGoal = DefaultWeeklyGoal
+	}
+
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	return &opts
+}
+
+// productivityPeriod sums the completed tasks of length days from start, dated by its first day.
+func productivityPeriod(days map[string]map[int]int, start time.Time, length int) ProductivityPeriod {
+	projects := make(map[int]int)
+	period := ProductivityPeriod{
+		Date:  start.Format(productivityDateLayout),
+		Items: make([]ProjectCompletedCount, 0),
+	}
+
+	for i := 0; i < length; i++ {
+		for projectId, completed := range days[start.AddDate(0, 0, i).Format(productivityDateLayout)] {
+			projects[projectId] += completed
+			period.TotalCompleted += completed
+		}
+	}
+
+	for projectId, completed := range projects {
+		period.Items = append(period.Items, ProjectCompletedCount{ProjectId: projectId, Completed: completed})
+	}
+
+	sort.Slice(period.Items, func(i, j int) bool {
+		if period.Items[i].Completed != period.Items[j].Completed {
+			return period.Items[i].Completed > period.Items[j].Completed
+		}
+
+		return period.Items[i].ProjectId < period.Items[j].ProjectId
+	})
+
+	return period
+}
+
+// productivityStreaks walks the periods from first to last. Skipped periods
+// neither break nor extend a streak and the last period, still in progress,
+// only extends it.
+func productivityStreaks(first time.Time, last time.Time, step int, reached func(date time.Time) (ok bool, skip bool)) (current Streak, longest Streak) {
+	for date := first; !date.After(last); date = date.AddDate(0, 0, step) {
+		ok, skip := reached(date)
+		if skip {
+			continue
+		}
+
+		if !ok {
+			if date.Before(last) {
+				current = Streak{}
+			}
+			continue
+		}
+
+		if current.Count == 0 {
+			current.Start = date.Format(productivityDateLayout)
+		}
+		current.Count++
+		current.End = date.AddDate(0, 0, step-1).Format(productivityDateLayout)
+
+		if current.Count > longest.Count {
+			longest = current
+		}
+	}
+
+	return
+}
+
+func startOfDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+}
+
+func startOfWeek(date time.Time) time.Time {
+	return date.AddDate(0, 0, -(int(date.Weekday())+6)%7)
+}
+
+// endregion
@@ -0,0 +1,88 @@
+package todoist
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGetProductivityStats(t *testing.T) {
+	cassette, err := LoadCassette("testdata/get_stats.json")
+	if err != nil {
+		t.Fatalf("load: %s", err)
+	}
+	client := New(&Opts{Token: "secret", Client: &http.Client{Transport: cassette}})
+
+	stats, err := client.GetProductivityStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetProductivityStats: %s", err)
+	}
+
+	wantDays := []ProductivityPeriod{
+		{Date: "2014-11-03", TotalCompleted: 7, Items: []ProjectCompletedCount{{ProjectId: 2235604758, Completed: 7}}},
+		{Date: "2014-11-02", Items: []ProjectCompletedCount{}},
+	}
+	if !reflect.DeepEqual(stats.DaysItems, wantDays) {
+		t.Errorf("days: got %+v, want %+v", stats.DaysItems, wantDays)
+	}
+
+	wantWeeks := []ProductivityWeek{
+		{From: "2014-11-03", To: "2014-11-09", TotalCompleted: 10, Items: []ProjectCompletedCount{{ProjectId: 2235604758, Completed: 7}, {ProjectId: 2235604759, Completed: 3}}},
+		{From: "2014-10-27", To: "2014-11-02", Items: []ProjectCompletedCount{}},
+	}
+	if !reflect.DeepEqual(stats.WeekItems, wantWeeks) {
+		t.Errorf("weeks: got %+v, want %+v", stats.WeekItems, wantWeeks)
+	}
+
+	if stats.Goals.MaxDailyStreak.Count != 4 || !reflect.DeepEqual(stats.Goals.IgnoreDays, []int{6, 7}) {
+		t.Errorf("goals: got %+v", stats.Goals)
+	}
+}
+
+func TestAggregateProductivityStats(t *testing.T) {
+	// Wednesday, so the current week started on 2024-05-13.
+	now := time.Date(2024, 5, 15, 18, 0, 0, 0, time.UTC)
+	completed := func(projectId int, date string) CompletedTask {
+		return CompletedTask{Task: Task{ProjectId: projectId}, CompletedDate: date}
+	}
+
+	tasks := []CompletedTask{
+		completed(1, "2024-05-15T09:00:00Z"),
+		completed(1, "2024-05-15T10:00:00Z"),
+		completed(2, "2024-05-14T10:00:00Z"),
+		completed(2, "2024-05-10T10:00:00Z"),
+	}
+
+	stats := AggregateProductivityStats(tasks, &ProductivityOpts{
+		Days:     2,
+		Weeks:    2,
+		Goals:    ProductivityGoals{DailyGoal: 1, WeeklyGoal: 3},
+		Location: time.UTC,
+		Now:      now,
+	})
+
+	wantDays := []ProductivityPeriod{
+		{Date: "2024-05-15", TotalCompleted: 2, Items: []ProjectCompletedCount{{ProjectId: 1, Completed: 2}}},
+		{Date: "2024-05-14", TotalCompleted: 1, Items: []ProjectCompletedCount{{ProjectId: 2, Completed: 1}}},
+	}
+	if !reflect.DeepEqual(stats.DaysItems, wantDays) {
+		t.Errorf("days: got %+v, want %+v", stats.DaysItems, wantDays)
+	}
+
+	wantWeeks := []ProductivityWeek{
+		{From: "2024-05-13", To: "2024-05-19", TotalCompleted: 3, Items: []ProjectCompletedCount{{ProjectId: 1, Completed: 2}, {ProjectId: 2, Completed: 1}}},
+		{From: "2024-05-06", To: "2024-05-12", TotalCompleted: 1, Items: []ProjectCompletedCount{{ProjectId: 2, Completed: 1}}},
+	}
+	if !reflect.DeepEqual(stats.WeekItems, wantWeeks) {
+		t.Errorf("weeks: got %+v, want %+v", stats.WeekItems, wantWeeks)
+	}
+
+	if want := (Streak{Count: 2, Start: "2024-05-14", End: "2024-05-15"}); stats.Goals.CurrentDailyStreak != want {
+		t.Errorf("daily streak: got %+v, want %+v", stats.Goals.CurrentDailyStreak, want)
+	}
+	if want := (Streak{Count: 1, Start: "2024-05-13", End: "2024-05-19"}); stats.Goals.CurrentWeeklyStreak != want {
+		t.Errorf("weekly streak: got %+v, want %+v", stats.Goals.CurrentWeeklyStreak, want)
+	}
+}
@@ -0,0 +1,257 @@
+package todoist
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// apiStub is an in-memory REST API for the tasks, projects, sections, labels
+// and comments collections, plus a Sync endpoint that accepts every command.
+// Objects are kept as decoded JSON, so only the fields sent by the client are set.
+// Other paths are answered from responses.
+type apiStub struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mutex       sync.Mutex
+	nextId      int
+	collections map[string]map[int]map[string]interface{}
+	resources   map[string]interface{}
+	responses   map[string]interface{}
+	commands    []SyncCommand
+	requests    []string
+}
+
+func newAPIStub(t *testing.T) *apiStub {
+	stub := &apiStub{
+		t:           t,
+		nextId:      100,
+		collections: make(map[string]map[int]map[string]interface{}),
+		resources:   make(map[string]interface{}),
+		responses:   make(map[string]interface{}),
+	}
+	for _, name := range []string{TasksEndpoint, ProjectsEndpoint, SectionsEndpoint, LabelsEndpoint, CommentsEndpoint} {
+		stub.collections[name] = make(map[int]map[string]interface{})
+	}
+
+	stub.server = httptest.NewServer(http.HandlerFunc(stub.serve))
+	t.Cleanup(stub.server.Close)
+
+	return stub
+}
+
+// client returns a client sending every request to the stub.
+func (s *apiStub) client() *Todoist {
+	return New(&Opts{Token: "secret", Client: &http.Client{Transport: rewriteHost(http.DefaultTransport, s.server.URL)}})
+}
+
+// add stores an object as if it was created earlier and returns its id.
+func (s *apiStub) add(collection string, object map[string]interface{}) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.insert(collection, object)
+}
+
+func (s *apiStub) insert(collection string, object map[string]interface{}) int {
+	id, ok := object["id"].(int)
+	if !ok {
+		s.nextId++
+		id = s.nextId
+	}
+
+	object["id"] = id
+	s.collections[collection][id] = object
+
+	return id
+}
+
+// objects returns the objects of the collection ordered by id.
+func (s *apiStub) objects(collection string) []map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.sorted(s.collections[collection])
+}
+
+// object returns a copy of the object with the id, or nil.
+func (s *apiStub) object(collection string, id int) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	object, ok := s.collections[collection][id]
+	if !ok {
+		return nil
+	}
+
+	copied := make(map[string]interface{}, len(object))
+	for key, value := range object {
+		copied[key] = value
+	}
+
+	return copied
+}
+
+func (s *apiStub) syncCommands() []SyncCommand {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]SyncCommand(nil), s.commands...)
+}
+
+func (s *apiStub) serve(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	w.Header().Set("Content-Type", "application/json")
+
+	var payload map[string]interface{}
+	if r.Body != nil && r.ContentLength != 0 {
+		decoder := json.NewDecoder(r.Body)
+		decoder.UseNumber()
+		if err := decoder.Decode(&payload); err != nil {
+			s.t.Errorf("%s %s: decode body: %s", r.Method, r.URL.Path, err)
+		}
+	}
+
+	if r.URL.Path == "/sync/v8/sync" {
+		s.sync(w, payload)
+		return
+	}
+
+	if response, ok := s.responses[r.URL.Path]; ok {
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/rest/v1/"), "/")
+	objects, ok := s.collections[parts[0]]
+	if !ok || len(parts) > 2 {
+		s.t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if len(parts) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			list := make([]map[string]interface{}, 0)
+			for _, object := range s.sorted(objects) {
+				if s.matches(object, r.URL.Query()) {
+					list = append(list, object)
+				}
+			}
+			_ = json.NewEncoder(w).Encode(list)
+		case http.MethodPost:
+			object := make(map[string]interface{}, len(payload)+1)
+			for key, value := range payload {
+				object[key] = value
+			}
+			s.insert(parts[0], object)
+			_ = json.NewEncoder(w).Encode(object)
+		}
+
+		return
+	}
+
+	id, _ := strconv.Atoi(parts[1])
+	object, ok := objects[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		_ = json.NewEncoder(w).Encode(object)
+	case http.MethodPost:
+		for key, value := range payload {
+			object[key] = value
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		delete(objects, id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// sync answers reads with the configured resources and accepts every command,
+// giving the created objects new ids.
+func (s *apiStub) sync(w http.ResponseWriter, payload map[string]interface{}) {
+	if resourceTypes, ok := payload["resource_types"].([]interface{}); ok {
+		res := map[string]interface{}{"sync_token": "token", "full_sync": true}
+		for _, resourceType := range resourceTypes {
+			if value, ok := s.resources[resourceType.(string)]; ok {
+				res[resourceType.(string)] = value
+			}
+		}
+		_ = json.NewEncoder(w).Encode(res)
+
+		return
+	}
+
+	data, _ := json.Marshal(payload["commands"])
+	commands := make([]SyncCommand, 0)
+	if err := json.Unmarshal(data, &commands); err != nil {
+		s.t.Errorf("sync: decode commands: %s", err)
+	}
+
+	statuses := make(map[string]string, len(commands))
+	tempIds := make(map[string]int)
+	for _, command := range commands {
+		statuses[command.Uuid] = "ok"
+		if command.TempId != "" {
+			s.nextId++
+			tempIds[command.TempId] = s.nextId
+		}
+	}
+	s.commands = append(s.commands, commands...)
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"sync_status": statuses, "temp_id_mapping": tempIds})
+}
+
+// matches filters lists by the project, section and task ids in the query.
+func (s *apiStub) matches(object map[string]interface{}, query url.Values) bool {
+	for _, key := range []string{"project_id", "section_id", "task_id"} {
+		if value := query.Get(key); value != "" && stubString(object[key]) != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *apiStub) sorted(objects map[int]map[string]interface{}) []map[string]interface{} {
+	ids := make([]int, 0, len(objects))
+	for id := range objects {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	sorted := make([]map[string]interface{}, len(ids))
+	for i, id := range ids {
+		sorted[i] = objects[id]
+	}
+
+	return sorted
+}
+
+func stubString(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		data, _ := json.Marshal(value)
+		return string(data)
+	}
+}
@@ -0,0 +1,126 @@
+package todoist
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const SyncBaseUrl = "https://api.todoist.com/sync/v8/"
+
+const SyncEndpoint = "sync"
+
+// Time filters of the Sync API are in UTC with minute precision.
+const syncTimeLayout = "2006-01-02T15:04"
+
+type SyncCommand struct {
+	Type   string      `json:"type"`
+	Uuid   string      `json:"uuid"`
+	TempId string      `json:"temp_id,omitempty"`
+	Args   interface{} `json:"args"`
+}
+
+type SyncResponse struct {
+	SyncStatus    map[string]json.RawMessage `json:"sync_status"`
+	TempIdMapping map[string]int             `json:"temp_id_mapping"`
+}
+
+type SyncError struct {
+	Command   string `json:"-"`
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"error"`
+}
+
+func (e *SyncError) Error() string {
+	return fmt.Sprintf("%s: %s (%d)", e.Command, e.Message, e.ErrorCode)
+}
+
+func MakeSyncCommand(commandType string, args interface{}) SyncCommand {
+	return SyncCommand{
+		Type: commandType,
+		Uuid: newUuid(),
+		Args: args,
+	}
+}
+
+// region Sync
+
+// Sync runs the commands in a single batch and returns the first command error, if any.
+func (t *Todoist) Sync(ctx context.Context, commands ...SyncCommand) (res *SyncResponse, err error) {
+	var payload []byte
+	if payload, err = json.Marshal(map[string]interface{}{"commands": commands}); err != nil {
+		return
+	}
+
+	res = new(SyncResponse)
+	if err = t.requestUrl(ctx, http.MethodPost, SyncBaseUrl, SyncEndpoint, nil, bytes.NewBuffer(payload), res); err != nil {
+		return
+	}
+
+	for _, command := range commands {
+		status, ok := res.SyncStatus[command.Uuid]
+		if !ok || string(status) == `"ok"` {
+			continue
+		}
+
+		syncError := &SyncError{Command: command.Type}
+		if err = json.Unmarshal(status, syncError); err != nil {
+			return res, fmt.Errorf("%s: unexpected status %s", command.Type, status)
+		}
+
+		return res, syncError
+	}
+
+	return
+}
+
+// endregion
+
+// region SyncRead
+
+// syncRead fetches full copies of the resources, such as "reminders" or
+// "filters", decoding the response into data.
+func (t *Todoist) syncRead(ctx context.Context, resourceTypes []string, data interface{}) (err error) {
+	var payload []byte
+	if payload, err = json.Marshal(map[string]interface{}{"sync_token": "*", "resource_types": resourceTypes}); err != nil {
+		return
+	}
+
+	return t.requestUrl(ctx, http.MethodPost, SyncBaseUrl, SyncEndpoint, nil, bytes.NewBuffer(payload), data)
+}
+
+// syncAdd runs a command creating an object and returns the id it was given.
+func (t *Todoist) syncAdd(ctx context.Context, commandType string, args interface{}) (id int, err error) {
+	command := MakeSyncCommand(commandType, args)
+	command.TempId = newUuid()
+
+	var res *SyncResponse
+	if res, err = t.Sync(ctx, command); err != nil {
+		return
+	}
+
+	var ok bool
+	if id, ok = res.TempIdMapping[command.TempId]; !ok {
+		return 0, fmt.Errorf("%s: missing id of the created object", commandType)
+	}
+
+	return
+}
+
+// endregion
+
+func newUuid() string {
+	uuid := make([]byte, 16)
+	_, _ = rand.Read(uuid)
+
+	uuid[6] = uuid[6]&0x0f | 0x40
+	uuid[8] = uuid[8]&0x3f | 0x80
+
+	value := hex.EncodeToString(uuid)
+
+	return value[0:8] + "-" + value[8:12] + "-" + value[12:16] + "-" + value[16:20] + "-" + value[20:32]
+}
@@ -359,6 +359,56 @@ func (t *Todoist) ReopenTask(ctx context.Context, taskId int) (err error) {
 
 // endregion
 
+// region MoveTask
+
+type MoveTaskParams map[string]interface{}
+
+//goland:noinspection GoUnusedExportedFunction
+func MakeMoveTaskParams() *MoveTaskParams {
+	params := make(MoveTaskParams)
+	return &params
+}
+
+func (p *MoveTaskParams) WithProjectId(projectId int) *MoveTaskParams {
+	if projectId != 0 {
+		(*p)["project_id"] = projectId
+	}
+
+	return p
+}
+
+func (p *MoveTaskParams) WithSectionId(sectionId int) *MoveTaskParams {
+	if sectionId != 0 {
+		(*p)["section_id"] = sectionId
+	}
+
+	return p
+}
+
+func (p *MoveTaskParams) WithParentId(parentId int) *MoveTaskParams {
+	if parentId != 0 {
+		(*p)["parent_id"] = parentId
+	}
+
+	return p
+}
+
+// MoveTask goes through the Sync API, since tasks cannot change their project,
+// section or parent via the REST one. Only one destination should be set.
+func (t *Todoist) MoveTask(ctx context.Context, taskId int, params *MoveTaskParams) (err error) {
+	args := make(map[string]interface{}, len(*params)+1)
+	for key, value := range *params {
+		args[key] = value
+	}
+	args["id"] = taskId
+
+	_, err = t.Sync(WithOperation(ctx, "MoveTask"), MakeSyncCommand("item_move", args))
+
+	return
+}
+
+// endregion
+
 // region DeleteTask
 
 func (t *Todoist) DeleteTask(ctx context.Context, taskId int) (err error) {
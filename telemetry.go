@@ -0,0 +1,147 @@
+package todoist
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const OperationPrefix = "todoist."
+
+const (
+	MetricRequests        = "todoist_requests_total"
+	MetricRequestErrors   = "todoist_request_errors_total"
+	MetricRequestRetries  = "todoist_request_retries_total"
+	MetricRequestDuration = "todoist_request_duration_seconds"
+)
+
+// Tracer starts a span per API call. Adapters for OpenTelemetry or other
+// tracing libraries only need to wrap their own tracer and span types.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// Meter records counters and histograms labelled by operation and status.
+type Meter interface {
+	AddCounter(name string, value float64, labels map[string]string)
+	RecordHistogram(name string, value float64, labels map[string]string)
+}
+
+type operationKey struct{}
+
+// WithOperation names the API calls made with ctx, for methods whose name
+// cannot be derived from the endpoint, such as the ones using the Sync API.
+func WithOperation(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, operationKey{}, name)
+}
+
+// operationName returns "todoist.GetTasks" for GET tasks, "todoist.CloseTask"
+// for POST tasks/1/close and so on, unless the context overrides it.
+func operationName(ctx context.Context, method string, baseUrl string, endpoint string) string {
+	if name, ok := ctx.Value(operationKey{}).(string); ok && name != "" {
+		return OperationPrefix + name
+	}
+
+	segments := strings.Split(strings.Trim(endpoint, "/"), "/")
+
+	if baseUrl != BaseUrl {
+		name := ""
+		for _, segment := range segments {
+			name += camelCase(segment)
+		}
+
+		return OperationPrefix + name
+	}
+
+	plural := camelCase(segments[0])
+	singular := strings.TrimSuffix(plural, "s")
+
+	switch {
+	case len(segments) == 1 && method == http.MethodGet:
+		return OperationPrefix + "Get" + plural
+	case len(segments) == 1:
+		return OperationPrefix + "Add" + singular
+	case len(segments) == 2 && method == http.MethodGet:
+		return OperationPrefix + "Get" + singular
+	case len(segments) == 2 && method == http.MethodDelete:
+		return OperationPrefix + "Delete" + singular
+	case len(segments) == 2:
+		return OperationPrefix + "Update" + singular
+	case method == http.MethodGet:
+		return OperationPrefix + "Get" + camelCase(segments[2])
+	default:
+		return OperationPrefix + camelCase(segments[2]) + singular
+	}
+}
+
+func camelCase(value string) string {
+	name := ""
+	for _, word := range strings.FieldsFunc(value, func(r rune) bool { return r == '_' || r == '-' }) {
+		name += strings.ToUpper(word[:1]) + word[1:]
+	}
+
+	return name
+}
+
+// startTelemetry starts the span for req and returns the function that ends
+// it and records the metrics once the request is done.
+func (t *Todoist) startTelemetry(ctx context.Context, req *Request) (context.Context, func(err error)) {
+	if t.opts.Tracer == nil && t.opts.Meter == nil {
+		return ctx, func(error) {}
+	}
+
+	start := time.Now()
+
+	var span Span
+	if t.opts.Tracer != nil {
+		ctx, span = t.opts.Tracer.Start(ctx, req.Operation)
+		span.SetAttribute("http.method", req.Method)
+		span.SetAttribute("todoist.endpoint", req.Endpoint)
+		span.SetAttribute("todoist.request_id", req.Header.Get(RequestIdHeader))
+
+		for _, segment := range strings.Split(req.Endpoint, "/") {
+			if id, err := strconv.Atoi(segment); err == nil {
+				span.SetAttribute("todoist.id", id)
+			}
+		}
+
+		for key, value := range req.Params {
+			if key == "ids" || strings.HasSuffix(key, "_id") {
+				span.SetAttribute("todoist."+key, value)
+			}
+		}
+	}
+
+	return ctx, func(err error) {
+		duration := time.Since(start)
+
+		if span != nil {
+			span.SetAttribute("http.status_code", req.StatusCode)
+			span.SetAttribute("todoist.retries", req.Retries)
+			if err != nil {
+				span.RecordError(err)
+			}
+			span.End()
+		}
+
+		if t.opts.Meter != nil {
+			labels := map[string]string{"operation": req.Operation, "status": strconv.Itoa(req.StatusCode)}
+			t.opts.Meter.AddCounter(MetricRequests, 1, labels)
+			if err != nil {
+				t.opts.Meter.AddCounter(MetricRequestErrors, 1, labels)
+			}
+			if req.Retries != 0 {
+				t.opts.Meter.AddCounter(MetricRequestRetries, float64(req.Retries), map[string]string{"operation": req.Operation})
+			}
+			t.opts.Meter.RecordHistogram(MetricRequestDuration, duration.Seconds(), map[string]string{"operation": req.Operation})
+		}
+	}
+}
@@ -0,0 +1,576 @@
+package todoistmock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/temoon/todoist-api"
+)
+
+// Call is a recorded method call. Args hold the arguments after the context.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Client implements todoist.Client. Every method records the call and then
+// calls the matching func field, failing with an error if it is not set.
+type Client struct {
+	GetTasksFunc                   func(ctx context.Context, params *todoist.GetTasksParams) ([]todoist.Task, error)
+	AddTaskFunc                    func(ctx context.Context, params *todoist.AddTaskParams) (*todoist.Task, error)
+	GetTaskFunc                    func(ctx context.Context, taskId int) (*todoist.Task, error)
+	UpdateTaskFunc                 func(ctx context.Context, taskId int, params *todoist.UpdateTaskParams) error
+	CloseTaskFunc                  func(ctx context.Context, taskId int) error
+	ReopenTaskFunc                 func(ctx context.Context, taskId int) error
+	MoveTaskFunc                   func(ctx context.Context, taskId int, params *todoist.MoveTaskParams) error
+	DeleteTaskFunc                 func(ctx context.Context, taskId int) error
+	GetCompletedTasksFunc          func(ctx context.Context, params *todoist.GetCompletedTasksParams) ([]todoist.CompletedTask, error)
+	GetProjectsFunc                func(ctx context.Context) ([]todoist.Project, error)
+	AddProjectFunc                 func(ctx context.Context, params *todoist.AddProjectParams) (*todoist.Project, error)
+	GetProjectFunc                 func(ctx context.Context, projectId int) (*todoist.Project, error)
+	UpdateProjectFunc              func(ctx context.Context, projectId int, params *todoist.UpdateProjectParams) error
+	DeleteProjectFunc              func(ctx context.Context, projectId int) error
+	GetCollaboratorsFunc           func(ctx context.Context, projectId int) ([]todoist.Collaborator, error)
+	ShareProjectFunc               func(ctx context.Context, projectId int, email string) error
+	DeleteCollaboratorFunc         func(ctx context.Context, projectId int, email string) error
+	GetInvitationsFunc             func(ctx context.Context) ([]todoist.Invitation, error)
+	AcceptInvitationFunc           func(ctx context.Context, invitation *todoist.Invitation) error
+	RejectInvitationFunc           func(ctx context.Context, invitation *todoist.Invitation) error
+	GetSectionsFunc                func(ctx context.Context, params *todoist.GetSectionsParams) ([]todoist.Section, error)
+	AddSectionFunc                 func(ctx context.Context, params *todoist.AddSectionParams) (*todoist.Section, error)
+	GetSectionFunc                 func(ctx context.Context, sectionId int) (*todoist.Section, error)
+	UpdateSectionFunc              func(ctx context.Context, sectionId int, params *todoist.UpdateSectionParams) error
+	DeleteSectionFunc              func(ctx context.Context, sectionId int) error
+	GetLabelsFunc                  func(ctx context.Context) ([]todoist.Label, error)
+	AddLabelFunc                   func(ctx context.Context, params *todoist.AddLabelParams) (*todoist.Label, error)
+	GetLabelFunc                   func(ctx context.Context, labelId int) (*todoist.Label, error)
+	UpdateLabelFunc                func(ctx context.Context, labelId int, params *todoist.UpdateLabelParams) error
+	DeleteLabelFunc                func(ctx context.Context, labelId int) error
+	GetCommentsFunc                func(ctx context.Context, params *todoist.GetCommentsParams) ([]todoist.Comment, error)
+	AddCommentFunc                 func(ctx context.Context, params *todoist.AddCommentParams) (*todoist.Comment, error)
+	GetCommentFunc                 func(ctx context.Context, commentId int) (*todoist.Comment, error)
+	UpdateCommentFunc              func(ctx context.Context, commentId int, params *todoist.UpdateCommentParams) error
+	DeleteCommentFunc              func(ctx context.Context, commentId int) error
+	GetRemindersFunc               func(ctx context.Context, params *todoist.GetRemindersParams) ([]todoist.Reminder, error)
+	AddReminderFunc                func(ctx context.Context, params *todoist.AddReminderParams) (*todoist.Reminder, error)
+	UpdateReminderFunc             func(ctx context.Context, reminderId int, params *todoist.UpdateReminderParams) error
+	DeleteReminderFunc             func(ctx context.Context, reminderId int) error
+	GetFiltersFunc                 func(ctx context.Context) ([]todoist.Filter, error)
+	AddFilterFunc                  func(ctx context.Context, params *todoist.AddFilterParams) (*todoist.Filter, error)
+	UpdateFilterFunc               func(ctx context.Context, filterId int, params *todoist.UpdateFilterParams) error
+	DeleteFilterFunc               func(ctx context.Context, filterId int) error
+	ReorderFiltersFunc             func(ctx context.Context, filterIds []int) error
+	GetFilterTasksFunc             func(ctx context.Context, filter *todoist.Filter) ([]todoist.Task, error)
+	GetUserFunc                    func(ctx context.Context) (*todoist.User, error)
+	UpdateUserFunc                 func(ctx context.Context, params *todoist.UpdateUserParams) error
+	UpdateNotificationSettingsFunc func(ctx context.Context, params *todoist.UpdateNotificationSettingsParams) error
+	GetActivityFunc                func(ctx context.Context, params *todoist.GetActivityParams) ([]todoist.ActivityEvent, error)
+	GetProductivityStatsFunc       func(ctx context.Context) (*todoist.ProductivityStats, error)
+	SyncFunc                       func(ctx context.Context, commands ...todoist.SyncCommand) (*todoist.SyncResponse, error)
+
+	mutex sync.Mutex
+	calls []Call
+}
+
+var _ todoist.Client = (*Client)(nil)
+
+// Calls returns the recorded calls in order.
+func (m *Client) Calls() []Call {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+// CallsTo returns the recorded calls of a single method.
+func (m *Client) CallsTo(method string) []Call {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	calls := make([]Call, 0)
+	for _, call := range m.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+func (m *Client) Reset() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.calls = nil
+}
+
+func (m *Client) record(method string, args ...interface{}) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+}
+
+func notProgrammed(method string) error {
+	return fmt.Errorf("todoistmock: %sFunc is not set", method)
+}
+
+func (m *Client) GetTasks(ctx context.Context, params *todoist.GetTasksParams) ([]todoist.Task, error) {
+	m.record("GetTasks", params)
+	if m.GetTasksFunc == nil {
+		return nil, notProgrammed("GetTasks")
+	}
+
+	return m.GetTasksFunc(ctx, params)
+}
+
+func (m *Client) AddTask(ctx context.Context, params *todoist.AddTaskParams) (*todoist.Task, error) {
+	m.record("AddTask", params)
+	if m.AddTaskFunc == nil {
+		return nil, notProgrammed("AddTask")
+	}
+
+	return m.AddTaskFunc(ctx, params)
+}
+
+func (m *Client) GetTask(ctx context.Context, taskId int) (*todoist.Task, error) {
+	m.record("GetTask", taskId)
+	if m.GetTaskFunc == nil {
+		return nil, notProgrammed("GetTask")
+	}
+
+	return m.GetTaskFunc(ctx, taskId)
+}
+
+func (m *Client) UpdateTask(ctx context.Context, taskId int, params *todoist.UpdateTaskParams) error {
+	m.record("UpdateTask", taskId, params)
+	if m.UpdateTaskFunc == nil {
+		return notProgrammed("UpdateTask")
+	}
+
+	return m.UpdateTaskFunc(ctx, taskId, params)
+}
+
+func (m *Client) CloseTask(ctx context.Context, taskId int) error {
+	m.record("CloseTask", taskId)
+	if m.CloseTaskFunc == nil {
+		return notProgrammed("CloseTask")
+	}
+
+	return m.CloseTaskFunc(ctx, taskId)
+}
+
+func (m *Client) ReopenTask(ctx context.Context, taskId int) error {
+	m.record("ReopenTask", taskId)
+	if m.ReopenTaskFunc == nil {
+		return notProgrammed("ReopenTask")
+	}
+
+	return m.ReopenTaskFunc(ctx, taskId)
+}
+
+func (m *Client) MoveTask(ctx context.Context, taskId int, params *todoist.MoveTaskParams) error {
+	m.record("MoveTask", taskId, params)
+	if m.MoveTaskFunc == nil {
+		return notProgrammed("MoveTask")
+	}
+
+	return m.MoveTaskFunc(ctx, taskId, params)
+}
+
+func (m *Client) DeleteTask(ctx context.Context, taskId int) error {
+	m.record("DeleteTask", taskId)
+	if m.DeleteTaskFunc == nil {
+		return notProgrammed("DeleteTask")
+	}
+
+	return m.DeleteTaskFunc(ctx, taskId)
+}
+
+func (m *Client) GetCompletedTasks(ctx context.Context, params *todoist.GetCompletedTasksParams) ([]todoist.CompletedTask, error) {
+	m.record("GetCompletedTasks", params)
+	if m.GetCompletedTasksFunc == nil {
+		return nil, notProgrammed("GetCompletedTasks")
+	}
+
+	return m.GetCompletedTasksFunc(ctx, params)
+}
+
+func (m *Client) GetProjects(ctx context.Context) ([]todoist.Project, error) {
+	m.record("GetProjects")
+	if m.GetProjectsFunc == nil {
+		return nil, notProgrammed("GetProjects")
+	}
+
+	return m.GetProjectsFunc(ctx)
+}
+
+func (m *Client) AddProject(ctx context.Context, params *todoist.AddProjectParams) (*todoist.Project, error) {
+	m.record("AddProject", params)
+	if m.AddProjectFunc == nil {
+		return nil, notProgrammed("AddProject")
+	}
+
+	return m.AddProjectFunc(ctx, params)
+}
+
+func (m *Client) GetProject(ctx context.Context, projectId int) (*todoist.Project, error) {
+	m.record("GetProject", projectId)
+	if m.GetProjectFunc == nil {
+		return nil, notProgrammed("GetProject")
+	}
+
+	return m.GetProjectFunc(ctx, projectId)
+}
+
+func (m *Client) UpdateProject(ctx context.Context, projectId int, params *todoist.UpdateProjectParams) error {
+	m.record("UpdateProject", projectId, params)
+	if m.UpdateProjectFunc == nil {
+		return notProgrammed("UpdateProject")
+	}
+
+	return m.UpdateProjectFunc(ctx, projectId, params)
+}
+
+func (m *Client) DeleteProject(ctx context.Context, projectId int) error {
+	m.record("DeleteProject", projectId)
+	if m.DeleteProjectFunc == nil {
+		return notProgrammed("DeleteProject")
+	}
+
+	return m.DeleteProjectFunc(ctx, projectId)
+}
+
+func (m *Client) GetCollaborators(ctx context.Context, projectId int) ([]todoist.Collaborator, error) {
+	m.record("GetCollaborators", projectId)
+	if m.GetCollaboratorsFunc == nil {
+		return nil, notProgrammed("GetCollaborators")
+	}
+
+	return m.GetCollaboratorsFunc(ctx, projectId)
+}
+
+func (m *Client) ShareProject(ctx context.Context, projectId int, email string) error {
+	m.record("ShareProject", projectId, email)
+	if m.ShareProjectFunc == nil {
+		return notProgrammed("ShareProject")
+	}
+
+	return m.ShareProjectFunc(ctx, projectId, email)
+}
+
+func (m *Client) DeleteCollaborator(ctx context.Context, projectId int, email string) error {
+	m.record("DeleteCollaborator", projectId, email)
+	if m.DeleteCollaboratorFunc == nil {
+		return notProgrammed("DeleteCollaborator")
+	}
+
+	return m.DeleteCollaboratorFunc(ctx, projectId, email)
+}
+
+func (m *Client) GetInvitations(ctx context.Context) ([]todoist.Invitation, error) {
+	m.record("GetInvitations")
+	if m.GetInvitationsFunc == nil {
+		return nil, notProgrammed("GetInvitations")
+	}
+
+	return m.GetInvitationsFunc(ctx)
+}
+
+func (m *Client) AcceptInvitation(ctx context.Context, invitation *todoist.Invitation) error {
+	m.record("AcceptInvitation", invitation)
+	if m.AcceptInvitationFunc == nil {
+		return notProgrammed("AcceptInvitation")
+	}
+
+	return m.AcceptInvitationFunc(ctx, invitation)
+}
+
+func (m *Client) RejectInvitation(ctx context.Context, invitation *todoist.Invitation) error {
+	m.record("RejectInvitation", invitation)
+	if m.RejectInvitationFunc == nil {
+		return notProgrammed("RejectInvitation")
+	}
+
+	return m.RejectInvitationFunc(ctx, invitation)
+}
+
+func (m *Client) GetSections(ctx context.Context, params *todoist.GetSectionsParams) ([]todoist.Section, error) {
+	m.record("GetSections", params)
+	if m.GetSectionsFunc == nil {
+		return nil, notProgrammed("GetSections")
+	}
+
+	return m.GetSectionsFunc(ctx, params)
+}
+
+func (m *Client) AddSection(ctx context.Context, params *todoist.AddSectionParams) (*todoist.Section, error) {
+	m.record("AddSection", params)
+	if m.AddSectionFunc == nil {
+		return nil, notProgrammed("AddSection")
+	}
+
+	return m.AddSectionFunc(ctx, params)
+}
+
+func (m *Client) GetSection(ctx context.Context, sectionId int) (*todoist.Section, error) {
+	m.record("GetSection", sectionId)
+	if m.GetSectionFunc == nil {
+		return nil, notProgrammed("GetSection")
+	}
+
+	return m.GetSectionFunc(ctx, sectionId)
+}
+
+func (m *Client) UpdateSection(ctx context.Context, sectionId int, params *todoist.UpdateSectionParams) error {
+	m.record("UpdateSection", sectionId, params)
+	if m.UpdateSectionFunc == nil {
+		return notProgrammed("UpdateSection")
+	}
+
+	return m.UpdateSectionFunc(ctx, sectionId, params)
+}
+
+func (m *Client) DeleteSection(ctx context.Context, sectionId int) error {
+	m.record("DeleteSection", sectionId)
+	if m.DeleteSectionFunc == nil {
+		return notProgrammed("DeleteSection")
+	}
+
+	return m.DeleteSectionFunc(ctx, sectionId)
+}
+
+func (m *Client) GetLabels(ctx context.Context) ([]todoist.Label, error) {
+	m.record("GetLabels")
+	if m.GetLabelsFunc == nil {
+		return nil, notProgrammed("GetLabels")
+	}
+
+	return m.GetLabelsFunc(ctx)
+}
+
+func (m *Client) AddLabel(ctx context.Context, params *todoist.AddLabelParams) (*todoist.Label, error) {
+	m.record("AddLabel", params)
+	if m.AddLabelFunc == nil {
+		return nil, notProgrammed("AddLabel")
+	}
+
+	return m.AddLabelFunc(ctx, params)
+}
+
+func (m *Client) GetLabel(ctx context.Context, labelId int) (*todoist.Label, error) {
+	m.record("GetLabel", labelId)
+	if m.GetLabelFunc == nil {
+		return nil, notProgrammed("GetLabel")
+	}
+
+	return m.GetLabelFunc(ctx, labelId)
+}
+
+func (m *Client) UpdateLabel(ctx context.Context, labelId int, params *todoist.UpdateLabelParams) error {
+	m.record("UpdateLabel", labelId, params)
+	if m.UpdateLabelFunc == nil {
+		return notProgrammed("UpdateLabel")
+	}
+
+	return m.UpdateLabelFunc(ctx, labelId, params)
+}
+
+func (m *Client) DeleteLabel(ctx context.Context, labelId int) error {
+	m.record("DeleteLabel", labelId)
+	if m.DeleteLabelFunc == nil {
+		return notProgrammed("DeleteLabel")
+	}
+
+	return m.DeleteLabelFunc(ctx, labelId)
+}
+
+func (m *Client) GetComments(ctx context.Context, params *todoist.GetCommentsParams) ([]todoist.Comment, error) {
+	m.record("GetComments", params)
+	if m.GetCommentsFunc == nil {
+		return nil, notProgrammed("GetComments")
+	}
+
+	return m.GetCommentsFunc(ctx, params)
+}
+
+func (m *Client) AddComment(ctx context.Context, params *todoist.AddCommentParams) (*todoist.Comment, error) {
+	m.record("AddComment", params)
+	if m.AddCommentFunc == nil {
+		return nil, notProgrammed("AddComment")
+	}
+
+	return m.AddCommentFunc(ctx, params)
+}
+
+func (m *Client) GetComment(ctx context.Context, commentId int) (*todoist.Comment, error) {
+	m.record("GetComment", commentId)
+	if m.GetCommentFunc == nil {
+		return nil, notProgrammed("GetComment")
+	}
+
+	return m.GetCommentFunc(ctx, commentId)
+}
+
+func (m *Client) UpdateComment(ctx context.Context, commentId int, params *todoist.UpdateCommentParams) error {
+	m.record("UpdateComment", commentId, params)
+	if m.UpdateCommentFunc == nil {
+		return notProgrammed("UpdateComment")
+	}
+
+	return m.UpdateCommentFunc(ctx, commentId, params)
+}
+
+func (m *Client) DeleteComment(ctx context.Context, commentId int) error {
+	m.record("DeleteComment", commentId)
+	if m.DeleteCommentFunc == nil {
+		return notProgrammed("DeleteComment")
+	}
+
+	return m.DeleteCommentFunc(ctx, commentId)
+}
+
+func (m *Client) GetReminders(ctx context.Context, params *todoist.GetRemindersParams) ([]todoist.Reminder, error) {
+	m.record("GetReminders", params)
+	if m.GetRemindersFunc == nil {
+		return nil, notProgrammed("GetReminders")
+	}
+
+	return m.GetRemindersFunc(ctx, params)
+}
+
+func (m *Client) AddReminder(ctx context.Context, params *todoist.AddReminderParams) (*todoist.Reminder, error) {
+	m.record("AddReminder", params)
+	if m.AddReminderFunc == nil {
+		return nil, notProgrammed("AddReminder")
+	}
+
+	return m.AddReminderFunc(ctx, params)
+}
+
+func (m *Client) UpdateReminder(ctx context.Context, reminderId int, params *todoist.UpdateReminderParams) error {
+	m.record("UpdateReminder", reminderId, params)
+	if m.UpdateReminderFunc == nil {
+		return notProgrammed("UpdateReminder")
+	}
+
+	return m.UpdateReminderFunc(ctx, reminderId, params)
+}
+
+func (m *Client) DeleteReminder(ctx context.Context, reminderId int) error {
+	m.record("DeleteReminder", reminderId)
+	if m.DeleteReminderFunc == nil {
+		return notProgrammed("DeleteReminder")
+	}
+
+	return m.DeleteReminderFunc(ctx, reminderId)
+}
+
+func (m *Client) GetFilters(ctx context.Context) ([]todoist.Filter, error) {
+	m.record("GetFilters")
+	if m.GetFiltersFunc == nil {
+		return nil, notProgrammed("GetFilters")
+	}
+
+	return m.GetFiltersFunc(ctx)
+}
+
+func (m *Client) AddFilter(ctx context.Context, params *todoist.AddFilterParams) (*todoist.Filter, error) {
+	m.record("AddFilter", params)
+	if m.AddFilterFunc == nil {
+		return nil, notProgrammed("AddFilter")
+	}
+
+	return m.AddFilterFunc(ctx, params)
+}
+
+func (m *Client) UpdateFilter(ctx context.Context, filterId int, params *todoist.UpdateFilterParams) error {
+	m.record("UpdateFilter", filterId, params)
+	if m.UpdateFilterFunc == nil {
+		return notProgrammed("UpdateFilter")
+	}
+
+	return m.UpdateFilterFunc(ctx, filterId, params)
+}
+
+func (m *Client) DeleteFilter(ctx context.Context, filterId int) error {
+	m.record("DeleteFilter", filterId)
+	if m.DeleteFilterFunc == nil {
+		return notProgrammed("DeleteFilter")
+	}
+
+	return m.DeleteFilterFunc(ctx, filterId)
+}
+
+func (m *Client) ReorderFilters(ctx context.Context, filterIds []int) error {
+	m.record("ReorderFilters", filterIds)
+	if m.ReorderFiltersFunc == nil {
+		return notProgrammed("ReorderFilters")
+	}
+
+	return m.ReorderFiltersFunc(ctx, filterIds)
+}
+
+func (m *Client) GetFilterTasks(ctx context.Context, filter *todoist.Filter) ([]todoist.Task, error) {
+	m.record("GetFilterTasks", filter)
+	if m.GetFilterTasksFunc == nil {
+		return nil, notProgrammed("GetFilterTasks")
+	}
+
+	return m.GetFilterTasksFunc(ctx, filter)
+}
+
+func (m *Client) GetUser(ctx context.Context) (*todoist.User, error) {
+	m.record("GetUser")
+	if m.GetUserFunc == nil {
+		return nil, notProgrammed("GetUser")
+	}
+
+	return m.GetUserFunc(ctx)
+}
+
+func (m *Client) UpdateUser(ctx context.Context, params *todoist.UpdateUserParams) error {
+	m.record("UpdateUser", params)
+	if m.UpdateUserFunc == nil {
+		return notProgrammed("UpdateUser")
+	}
+
+	return m.UpdateUserFunc(ctx, params)
+}
+
+func (m *Client) UpdateNotificationSettings(ctx context.Context, params *todoist.UpdateNotificationSettingsParams) error {
+	m.record("UpdateNotificationSettings", params)
+	if m.UpdateNotificationSettingsFunc == nil {
+		return notProgrammed("UpdateNotificationSettings")
+	}
+
+	return m.UpdateNotificationSettingsFunc(ctx, params)
+}
+
+func (m *Client) GetActivity(ctx context.Context, params *todoist.GetActivityParams) ([]todoist.ActivityEvent, error) {
+	m.record("GetActivity", params)
+	if m.GetActivityFunc == nil {
+		return nil, notProgrammed("GetActivity")
+	}
+
+	return m.GetActivityFunc(ctx, params)
+}
+
+func (m *Client) GetProductivityStats(ctx context.Context) (*todoist.ProductivityStats, error) {
+	m.record("GetProductivityStats")
+	if m.GetProductivityStatsFunc == nil {
+		return nil, notProgrammed("GetProductivityStats")
+	}
+
+	return m.GetProductivityStatsFunc(ctx)
+}
+
+func (m *Client) Sync(ctx context.Context, commands ...todoist.SyncCommand) (*todoist.SyncResponse, error) {
+	m.record("Sync", commands)
+	if m.SyncFunc == nil {
+		return nil, notProgrammed("Sync")
+	}
+
+	return m.SyncFunc(ctx, commands...)
+}
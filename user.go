@@ -0,0 +1,295 @@
+package todoist
+
+import (
+	"context"
+	"time"
+)
+
+type User struct {
+	Id              int     `json:"id"`
+	Email           string  `json:"email"`
+	FullName        string  `json:"full_name"`
+	TzInfo          TzInfo  `json:"tz_info"`
+	Lang            string  `json:"lang"`
+	StartDay        int     `json:"start_day"`
+	NextWeek        int     `json:"next_week"`
+	StartPage       string  `json:"start_page"`
+	DateFormat      int     `json:"date_format"`
+	TimeFormat      int     `json:"time_format"`
+	SortOrder       int     `json:"sort_order"`
+	IsPremium       bool    `json:"is_premium"`
+	PremiumUntil    string  `json:"premium_until"`
+	InboxProjectId  int     `json:"inbox_project"`
+	TeamInboxId     int     `json:"team_inbox"`
+	DailyGoal       int     `json:"daily_goal"`
+	WeeklyGoal      int     `json:"weekly_goal"`
+	DaysOff         []int   `json:"days_off"`
+	Karma           float64 `json:"karma"`
+	KarmaTrend      string  `json:"karma_trend"`
+	CompletedCount  int     `json:"completed_count"`
+	CompletedToday  int     `json:"completed_today"`
+	AutoReminder    int     `json:"auto_reminder"`
+	DefaultReminder string  `json:"default_reminder"`
+	AvatarBig       string  `json:"avatar_big"`
+}
+
+type TzInfo struct {
+	Timezone  string `json:"timezone"`
+	GmtString string `json:"gmt_string"`
+	Hours     int    `json:"hours"`
+	Minutes   int    `json:"minutes"`
+	IsDst     int    `json:"is_dst"`
+}
+
+// Location loads the time zone of the user, falling back to the fixed offset
+// when the zone is not in the local time zone database.
+func (z *TzInfo) Location() *time.Location {
+	if location, err := time.LoadLocation(z.Timezone); z.Timezone != "" && err == nil {
+		return location
+	}
+
+	offset := z.Hours*60*60 + z.Minutes*60
+	if z.Hours < 0 {
+		offset = z.Hours*60*60 - z.Minutes*60
+	}
+
+	return time.FixedZone(z.GmtString, offset)
+}
+
+// StartWeekday returns the first day of the week, which the API sends as 1 for Monday to 7 for Sunday.
+func (u *User) StartWeekday() time.Weekday {
+	return time.Weekday(u.StartDay % 7)
+}
+
+// region GetUser
+
+func (t *Todoist) GetUser(ctx context.Context) (user *User, err error) {
+	res := &struct {
+		User *User `json:"user"`
+	}{}
+
+	if err = t.syncRead(WithOperation(ctx, "GetUser"), []string{"user"}, res); err != nil {
+		return
+	}
+
+	if res.User == nil {
+		return new(User), nil
+	}
+
+	return res.User, nil
+}
+
+// endregion
+
+// region UpdateUser
+
+type UpdateUserParams map[string]interface{}
+
+// Goals are changed with a separate command, sent in the same batch.
+var userGoalParams = map[string]bool{
+	"daily_goal":     true,
+	"weekly_goal":    true,
+	"ignore_days":    true,
+	"vacation_mode":  true,
+	"karma_disabled": true,
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func MakeUpdateUserParams() *UpdateUserParams {
+	params := make(UpdateUserParams)
+	return &params
+}
+
+func (p *UpdateUserParams) WithFullName(fullName string) *UpdateUserParams {
+	if fullName != "" {
+		(*p)["full_name"] = fullName
+	}
+
+	return p
+}
+
+// WithEmail changes the email, which needs the current password.
+func (p *UpdateUserParams) WithEmail(email string, currentPassword string) *UpdateUserParams {
+	if email != "" {
+		(*p)["email"] = email
+		(*p)["current_password"] = currentPassword
+	}
+
+	return p
+}
+
+func (p *UpdateUserParams) WithTimezone(timezone string) *UpdateUserParams {
+	if timezone != "" {
+		(*p)["timezone"] = timezone
+	}
+
+	return p
+}
+
+func (p *UpdateUserParams) WithLang(lang string) *UpdateUserParams {
+	if lang != "" {
+		(*p)["lang"] = lang
+	}
+
+	return p
+}
+
+func (p *UpdateUserParams) WithStartDay(startDay int) *UpdateUserParams {
+	if startDay != 0 {
+		(*p)["start_day"] = startDay
+	}
+
+	return p
+}
+
+func (p *UpdateUserParams) WithNextWeek(nextWeek int) *UpdateUserParams {
+	if nextWeek != 0 {
+		(*p)["next_week"] = nextWeek
+	}
+
+	return p
+}
+
+func (p *UpdateUserParams) WithStartPage(startPage string) *UpdateUserParams {
+	if startPage != "" {
+		(*p)["start_page"] = startPage
+	}
+
+	return p
+}
+
+func (p *UpdateUserParams) WithDateFormat(dateFormat int) *UpdateUserParams {
+	(*p)["date_format"] = dateFormat
+	return p
+}
+
+func (p *UpdateUserParams) WithTimeFormat(timeFormat int) *UpdateUserParams {
+	(*p)["time_format"] = timeFormat
+	return p
+}
+
+func (p *UpdateUserParams) WithDefaultReminder(defaultReminder string) *UpdateUserParams {
+	if defaultReminder != "" {
+		(*p)["default_reminder"] = defaultReminder
+	}
+
+	return p
+}
+
+func (p *UpdateUserParams) WithAutoReminder(autoReminder int) *UpdateUserParams {
+	(*p)["auto_reminder"] = autoReminder
+	return p
+}
+
+func (p *UpdateUserParams) WithDailyGoal(dailyGoal int) *UpdateUserParams {
+	if dailyGoal != 0 {
+		(*p)["daily_goal"] = dailyGoal
+	}
+
+	return p
+}
+
+func (p *UpdateUserParams) WithWeeklyGoal(weeklyGoal int) *UpdateUserParams {
+	if weeklyGoal != 0 {
+		(*p)["weekly_goal"] = weeklyGoal
+	}
+
+	return p
+}
+
+// WithIgnoreDays sets the days, from 1 for Monday to 7 for Sunday, that do not break streaks.
+func (p *UpdateUserParams) WithIgnoreDays(ignoreDays []int) *UpdateUserParams {
+	if ignoreDays != nil {
+		(*p)["ignore_days"] = ignoreDays
+	}
+
+	return p
+}
+
+func (p *UpdateUserParams) WithVacationMode(vacationMode bool) *UpdateUserParams {
+	(*p)["vacation_mode"] = boolToInt(vacationMode)
+	return p
+}
+
+func (p *UpdateUserParams) WithKarmaDisabled(karmaDisabled bool) *UpdateUserParams {
+	(*p)["karma_disabled"] = boolToInt(karmaDisabled)
+	return p
+}
+
+func (t *Todoist) UpdateUser(ctx context.Context, params *UpdateUserParams) (err error) {
+	user := make(map[string]interface{})
+	goals := make(map[string]interface{})
+	for key, value := range *params {
+		if userGoalParams[key] {
+			goals[key] = value
+		} else {
+			user[key] = value
+		}
+	}
+
+	commands := make([]SyncCommand, 0, 2)
+	if len(user) != 0 {
+		commands = append(commands, MakeSyncCommand("user_update", user))
+	}
+	if len(goals) != 0 {
+		commands = append(commands, MakeSyncCommand("update_goals", goals))
+	}
+	if len(commands) == 0 {
+		return
+	}
+
+	_, err = t.Sync(WithOperation(ctx, "UpdateUser"), commands...)
+
+	return
+}
+
+func boolToInt(value bool) int {
+	if value {
+		return 1
+	}
+
+	return 0
+}
+
+// endregion
+
+// region UpdateNotificationSettings
+
+type UpdateNotificationSettingsParams map[string]interface{}
+
+//goland:noinspection GoUnusedExportedFunction
+func MakeUpdateNotificationSettingsParams() *UpdateNotificationSettingsParams {
+	params := make(UpdateNotificationSettingsParams)
+	return &params
+}
+
+// WithNotificationType selects the notification, such as "item_completed" or "note_added".
+func (p *UpdateNotificationSettingsParams) WithNotificationType(notificationType string) *UpdateNotificationSettingsParams {
+	if notificationType != "" {
+		(*p)["notification_type"] = notificationType
+	}
+
+	return p
+}
+
+// WithService selects the delivery channel, "email" or "push".
+func (p *UpdateNotificationSettingsParams) WithService(service string) *UpdateNotificationSettingsParams {
+	if service != "" {
+		(*p)["service"] = service
+	}
+
+	return p
+}
+
+func (p *UpdateNotificationSettingsParams) WithDontNotify(dontNotify bool) *UpdateNotificationSettingsParams {
+	(*p)["dont_notify"] = boolToInt(dontNotify)
+	return p
+}
+
+func (t *Todoist) UpdateNotificationSettings(ctx context.Context, params *UpdateNotificationSettingsParams) (err error) {
+	_, err = t.Sync(WithOperation(ctx, "UpdateNotificationSettings"), MakeSyncCommand("update_notification_setting", params))
+
+	return
+}
+
+// endregion
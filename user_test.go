@@ -0,0 +1,119 @@
+package todoist
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGetUser(t *testing.T) {
+	stub := newAPIStub(t)
+	stub.resources["user"] = map[string]interface{}{
+		"id": 8, "email": "ann@example.com", "full_name": "Ann", "lang": "de", "start_day": 7,
+		"is_premium": true, "inbox_project": 100, "daily_goal": 5, "days_off": []int{6, 7},
+		"tz_info": map[string]interface{}{"timezone": "Europe/Berlin", "gmt_string": "+01:00", "hours": 1, "minutes": 0, "is_dst": 0},
+	}
+
+	user, err := stub.client().GetUser(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &User{
+		Id: 8, Email: "ann@example.com", FullName: "Ann", Lang: "de", StartDay: 7,
+		IsPremium: true, InboxProjectId: 100, DailyGoal: 5, DaysOff: []int{6, 7},
+		TzInfo: TzInfo{Timezone: "Europe/Berlin", GmtString: "+01:00", Hours: 1},
+	}
+	if !reflect.DeepEqual(user, want) {
+		t.Errorf("got %+v, want %+v", user, want)
+	}
+	if user.StartWeekday() != time.Sunday {
+		t.Errorf("got start weekday %s, want Sunday", user.StartWeekday())
+	}
+}
+
+func TestGetUserMissing(t *testing.T) {
+	stub := newAPIStub(t)
+
+	user, err := stub.client().GetUser(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user == nil || user.Id != 0 {
+		t.Errorf("got %+v, want an empty user", user)
+	}
+}
+
+func TestTzInfoLocation(t *testing.T) {
+	tests := []struct {
+		tzInfo     TzInfo
+		wantName   string
+		wantOffset int
+	}{
+		{TzInfo{Timezone: "Europe/Berlin", GmtString: "+01:00", Hours: 1}, "Europe/Berlin", 60 * 60},
+		{TzInfo{Timezone: "Mars/Olympus", GmtString: "+05:30", Hours: 5, Minutes: 30}, "+05:30", 5*60*60 + 30*60},
+		{TzInfo{GmtString: "-03:30", Hours: -3, Minutes: 30}, "-03:30", -3*60*60 - 30*60},
+	}
+
+	for _, test := range tests {
+		location := test.tzInfo.Location()
+		_, offset := time.Date(2024, time.January, 15, 12, 0, 0, 0, location).Zone()
+		if location.String() != test.wantName || offset != test.wantOffset {
+			t.Errorf("%+v: got %s %d, want %s %d", test.tzInfo, location, offset, test.wantName, test.wantOffset)
+		}
+	}
+}
+
+func TestUpdateUser(t *testing.T) {
+	tests := []struct {
+		name         string
+		params       *UpdateUserParams
+		wantCommands []SyncCommand
+	}{
+		{
+			name:         "nothing",
+			params:       MakeUpdateUserParams(),
+			wantCommands: nil,
+		},
+		{
+			name:   "profile",
+			params: MakeUpdateUserParams().WithFullName("Ann").WithEmail("new@example.com", "hunter2"),
+			wantCommands: []SyncCommand{
+				{Type: "user_update", Args: map[string]interface{}{"full_name": "Ann", "email": "new@example.com", "current_password": "hunter2"}},
+			},
+		},
+		{
+			name:   "goals",
+			params: MakeUpdateUserParams().WithDailyGoal(5).WithIgnoreDays([]int{6, 7}).WithVacationMode(true),
+			wantCommands: []SyncCommand{
+				{Type: "update_goals", Args: map[string]interface{}{"daily_goal": 5.0, "ignore_days": []interface{}{6.0, 7.0}, "vacation_mode": 1.0}},
+			},
+		},
+		{
+			name:   "both",
+			params: MakeUpdateUserParams().WithLang("de").WithKarmaDisabled(false),
+			wantCommands: []SyncCommand{
+				{Type: "user_update", Args: map[string]interface{}{"lang": "de"}},
+				{Type: "update_goals", Args: map[string]interface{}{"karma_disabled": 0.0}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stub := newAPIStub(t)
+			if err := stub.client().UpdateUser(context.Background(), test.params); err != nil {
+				t.Fatal(err)
+			}
+
+			commands := stub.syncCommands()
+			for i := range commands {
+				commands[i].Uuid = ""
+			}
+			if !reflect.DeepEqual(commands, test.wantCommands) {
+				t.Errorf("got %+v, want %+v", commands, test.wantCommands)
+			}
+		})
+	}
+}
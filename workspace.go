@@ -0,0 +1,397 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	WorkspaceCreate = "create"
+	WorkspaceUpdate = "update"
+	WorkspaceDelete = "delete"
+)
+
+const (
+	WorkspaceProject = "project"
+	WorkspaceSection = "section"
+	WorkspaceLabel   = "label"
+)
+
+type WorkspaceSpec struct {
+	Projects []ProjectSpec `json:"projects" yaml:"projects"`
+	Labels   []LabelSpec   `json:"labels" yaml:"labels"`
+}
+
+type ProjectSpec struct {
+	Name     string        `json:"name" yaml:"name"`
+	Color    int           `json:"color" yaml:"color"`
+	Favorite *bool         `json:"favorite,omitempty" yaml:"favorite,omitempty"`
+	Sections []string      `json:"sections" yaml:"sections"`
+	Projects []ProjectSpec `json:"projects" yaml:"projects"`
+}
+
+type LabelSpec struct {
+	Name     string `json:"name" yaml:"name"`
+	Color    int    `json:"color" yaml:"color"`
+	Favorite *bool  `json:"favorite,omitempty" yaml:"favorite,omitempty"`
+}
+
+type WorkspaceAction struct {
+	Kind     string
+	Object   string
+	Id       int
+	Path     string
+	Parent   string
+	Name     string
+	Order    int
+	Color    int
+	Favorite *bool
+	Changes  []string
+}
+
+type WorkspacePlan struct {
+	Actions []WorkspaceAction
+
+	projectIds map[string]int
+}
+
+// region ParseWorkspaceSpec
+
+// YAML is a superset of JSON, so the same decoder handles both formats.
+func ParseWorkspaceSpec(r io.Reader) (spec *WorkspaceSpec, err error) {
+	spec = new(WorkspaceSpec)
+	if err = yaml.NewDecoder(r).Decode(spec); err != nil {
+		if errors.Is(err, io.EOF) {
+			return spec, nil
+		}
+
+		return nil, err
+	}
+
+	return spec, spec.validate()
+}
+
+func (s *WorkspaceSpec) validate() error {
+	labels := make(map[string]bool)
+	for _, label := range s.Labels {
+		if label.Name == "" {
+			return errors.New("label without name")
+		}
+
+		if labels[label.Name] {
+			return fmt.Errorf("duplicate label %q", label.Name)
+		}
+		labels[label.Name] = true
+	}
+
+	return validateProjectSpecs(s.Projects, "")
+}
+
+func validateProjectSpecs(projects []ProjectSpec, parent string) error {
+	names := make(map[string]bool)
+	for _, project := range projects {
+		if project.Name == "" || strings.Contains(project.Name, ProjectPathSeparator) {
+			return fmt.Errorf("invalid project name %q in %q", project.Name, parent)
+		}
+
+		if names[project.Name] {
+			return fmt.Errorf("duplicate project %q", joinProjectPath(parent, project.Name))
+		}
+		names[project.Name] = true
+
+		sections := make(map[string]bool)
+		for _, section := range project.Sections {
+			if section == "" || sections[section] {
+				return fmt.Errorf("invalid or duplicate section %q in %q", section, joinProjectPath(parent, project.Name))
+			}
+			sections[section] = true
+		}
+
+		if err := validateProjectSpecs(project.Projects, joinProjectPath(parent, project.Name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// endregion
+
+// region PlanWorkspace
+
+func (t *Todoist) PlanWorkspace(ctx context.Context, spec *WorkspaceSpec, prune bool) (plan *WorkspacePlan, err error) {
+	var tree *ProjectTree
+	if tree, err = t.GetProjectTree(ctx); err != nil {
+		return
+	}
+
+	var labels []Label
+	if labels, err = t.GetLabels(ctx); err != nil {
+		return
+	}
+
+	plan = &WorkspacePlan{
+		Actions:    make([]WorkspaceAction, 0),
+		projectIds: make(map[string]int),
+	}
+
+	managed := make(map[int]bool)
+	deletes := make([]WorkspaceAction, 0)
+	if err = t.planProjects(ctx, plan, &deletes, tree, spec.Projects, "", managed, prune); err != nil {
+		return
+	}
+
+	if prune {
+		tree.Walk(func(node *ProjectNode) bool {
+			if !managed[node.Id] && (node.Parent == nil || managed[node.Parent.Id]) && !node.InboxProject && !node.TeamInbox {
+				deletes = append(deletes, WorkspaceAction{Kind: WorkspaceDelete, Object: WorkspaceProject, Id: node.Id, Path: node.Path()})
+			}
+
+			return true
+		})
+	}
+
+	existing := make(map[string]Label, len(labels))
+	for _, label := range labels {
+		existing[label.Name] = label
+	}
+
+	for _, spec := range spec.Labels {
+		label, ok := existing[spec.Name]
+		if !ok {
+			plan.Actions = append(plan.Actions, WorkspaceAction{Kind: WorkspaceCreate, Object: WorkspaceLabel, Path: spec.Name, Name: spec.Name, Color: spec.Color, Favorite: spec.Favorite})
+			continue
+		}
+		delete(existing, spec.Name)
+
+		if changes := diffAppearance(label.Color, label.Favorite, spec.Color, spec.Favorite); len(changes) != 0 {
+			plan.Actions = append(plan.Actions, WorkspaceAction{Kind: WorkspaceUpdate, Object: WorkspaceLabel, Id: label.Id, Path: spec.Name, Name: spec.Name, Color: spec.Color, Favorite: spec.Favorite, Changes: changes})
+		}
+	}
+
+	if prune {
+		for _, label := range labels {
+			if _, ok := existing[label.Name]; ok {
+				deletes = append(deletes, WorkspaceAction{Kind: WorkspaceDelete, Object: WorkspaceLabel, Id: label.Id, Path: label.Name})
+			}
+		}
+	}
+
+	plan.Actions = append(plan.Actions, deletes...)
+
+	return
+}
+
+func (t *Todoist) planProjects(ctx context.Context, plan *WorkspacePlan, deletes *[]WorkspaceAction, tree *ProjectTree, specs []ProjectSpec, parent string, managed map[int]bool, prune bool) (err error) {
+	for _, spec := range specs {
+		path := joinProjectPath(parent, spec.Name)
+
+		node := tree.Lookup(path)
+		if node == nil {
+			plan.Actions = append(plan.Actions, WorkspaceAction{Kind: WorkspaceCreate, Object: WorkspaceProject, Path: path, Parent: parent, Name: spec.Name, Color: spec.Color, Favorite: spec.Favorite})
+			for i, section := range spec.Sections {
+				plan.Actions = append(plan.Actions, WorkspaceAction{Kind: WorkspaceCreate, Object: WorkspaceSection, Path: path + ProjectPathSeparator + section, Parent: path, Name: section, Order: i + 1})
+			}
+		} else {
+			managed[node.Id] = true
+			plan.projectIds[path] = node.Id
+
+			if changes := diffAppearance(node.Color, node.Favorite, spec.Color, spec.Favorite); len(changes) != 0 {
+				plan.Actions = append(plan.Actions, WorkspaceAction{Kind: WorkspaceUpdate, Object: WorkspaceProject, Id: node.Id, Path: path, Name: spec.Name, Color: spec.Color, Favorite: spec.Favorite, Changes: changes})
+			}
+
+			var sections []Section
+			if sections, err = t.GetSections(ctx, MakeGetSectionsParams().WithProjectId(node.Id)); err != nil {
+				return
+			}
+
+			existing := make(map[string]bool, len(sections))
+			for _, section := range sections {
+				existing[section.Name] = true
+			}
+
+			wanted := make(map[string]bool, len(spec.Sections))
+			for i, section := range spec.Sections {
+				wanted[section] = true
+				if !existing[section] {
+					plan.Actions = append(plan.Actions, WorkspaceAction{Kind: WorkspaceCreate, Object: WorkspaceSection, Path: path + ProjectPathSeparator + section, Parent: path, Name: section, Order: i + 1})
+				}
+			}
+
+			if prune {
+				for _, section := range sections {
+					if !wanted[section.Name] {
+						*deletes = append(*deletes, WorkspaceAction{Kind: WorkspaceDelete, Object: WorkspaceSection, Id: section.Id, Path: path + ProjectPathSeparator + section.Name})
+					}
+				}
+			}
+		}
+
+		if err = t.planProjects(ctx, plan, deletes, tree, spec.Projects, path, managed, prune); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Colors and favorites missing from the spec are left as they are.
+func diffAppearance(color int, favorite bool, wantColor int, wantFavorite *bool) []string {
+	changes := make([]string, 0)
+	if wantColor != 0 && wantColor != color {
+		changes = append(changes, "color: "+strconv.Itoa(color)+" -> "+strconv.Itoa(wantColor))
+	}
+
+	if wantFavorite != nil && *wantFavorite != favorite {
+		changes = append(changes, "favorite: "+strconv.FormatBool(favorite)+" -> "+strconv.FormatBool(*wantFavorite))
+	}
+
+	return changes
+}
+
+func joinProjectPath(parent string, name string) string {
+	if parent == "" {
+		return name
+	}
+
+	return parent + ProjectPathSeparator + name
+}
+
+// endregion
+
+// region WorkspacePlan
+
+func (p *WorkspacePlan) Empty() bool {
+	return len(p.Actions) == 0
+}
+
+func (p *WorkspacePlan) WriteTo(w io.Writer) (n int64, err error) {
+	var count int
+	if p.Empty() {
+		count, err = io.WriteString(w, "No changes.\n")
+		return int64(count), err
+	}
+
+	counts := make(map[string]int)
+	for _, action := range p.Actions {
+		counts[action.Kind]++
+
+		line := strings.Builder{}
+		switch action.Kind {
+		case WorkspaceCreate:
+			line.WriteString("+ ")
+		case WorkspaceUpdate:
+			line.WriteString("~ ")
+		case WorkspaceDelete:
+			line.WriteString("- ")
+		}
+
+		line.WriteString(action.Object)
+		line.WriteByte(' ')
+		line.WriteString(action.Path)
+		if len(action.Changes) != 0 {
+			line.WriteString(" (")
+			line.WriteString(strings.Join(action.Changes, ", "))
+			line.WriteByte(')')
+		}
+		line.WriteByte('\n')
+
+		if count, err = io.WriteString(w, line.String()); err != nil {
+			return
+		}
+		n += int64(count)
+	}
+
+	count, err = fmt.Fprintf(w, "\nPlan: %d to create, %d to update, %d to delete.\n", counts[WorkspaceCreate], counts[WorkspaceUpdate], counts[WorkspaceDelete])
+	n += int64(count)
+
+	return
+}
+
+func (p *WorkspacePlan) String() string {
+	builder := strings.Builder{}
+	_, _ = p.WriteTo(&builder)
+
+	return builder.String()
+}
+
+// endregion
+
+// region ApplyWorkspace
+
+func (t *Todoist) ApplyWorkspace(ctx context.Context, plan *WorkspacePlan) (err error) {
+	ids := make(map[string]int, len(plan.projectIds))
+	for path, id := range plan.projectIds {
+		ids[path] = id
+	}
+
+	for _, action := range plan.Actions {
+		if err = t.applyWorkspaceAction(ctx, action, ids); err != nil {
+			return fmt.Errorf("%s %s %q: %w", action.Kind, action.Object, action.Path, err)
+		}
+	}
+
+	return
+}
+
+func (t *Todoist) applyWorkspaceAction(ctx context.Context, action WorkspaceAction, ids map[string]int) (err error) {
+	switch action.Object + ":" + action.Kind {
+	case WorkspaceProject + ":" + WorkspaceCreate:
+		params := MakeAddProjectParams().WithName(action.Name).WithColor(action.Color)
+		if action.Favorite != nil {
+			params.WithFavorite(*action.Favorite)
+		}
+		if action.Parent != "" {
+			params.WithParentId(ids[action.Parent])
+		}
+
+		var project *Project
+		if project, err = t.AddProject(ctx, params); err != nil {
+			return
+		}
+		ids[action.Path] = project.Id
+
+		return
+	case WorkspaceProject + ":" + WorkspaceUpdate:
+		params := MakeUpdateProjectParams().WithColor(action.Color)
+		if action.Favorite != nil {
+			params.WithFavorite(*action.Favorite)
+		}
+
+		return t.UpdateProject(ctx, action.Id, params)
+	case WorkspaceProject + ":" + WorkspaceDelete:
+		return t.DeleteProject(ctx, action.Id)
+	case WorkspaceSection + ":" + WorkspaceCreate:
+		_, err = t.AddSection(ctx, MakeAddSectionParams().WithProjectId(ids[action.Parent]).WithName(action.Name).WithOrder(action.Order))
+		return
+	case WorkspaceSection + ":" + WorkspaceDelete:
+		return t.DeleteSection(ctx, action.Id)
+	case WorkspaceLabel + ":" + WorkspaceCreate:
+		params := MakeAddLabelParams().WithName(action.Name).WithColor(action.Color)
+		if action.Favorite != nil {
+			params.WithFavorite(*action.Favorite)
+		}
+
+		_, err = t.AddLabel(ctx, params)
+		return
+	case WorkspaceLabel + ":" + WorkspaceUpdate:
+		params := MakeUpdateLabelParams().WithColor(action.Color)
+		if action.Favorite != nil {
+			params.WithFavorite(*action.Favorite)
+		}
+
+		return t.UpdateLabel(ctx, action.Id, params)
+	case WorkspaceLabel + ":" + WorkspaceDelete:
+		return t.DeleteLabel(ctx, action.Id)
+	default:
+		return errors.New("unsupported action")
+	}
+}
+
+// endregion
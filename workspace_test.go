@@ -0,0 +1,177 @@
+package todoist
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const workspaceSpec = `
+projects:
+  - name: Work
+    color: 31
+    sections: [Now, Later]
+    projects:
+      - name: Reports
+        sections: [Q1]
+labels:
+  - name: urgent
+  - name: new
+    favorite: true
+`
+
+func newWorkspaceStub(t *testing.T) *apiStub {
+	stub := newAPIStub(t)
+	stub.add(ProjectsEndpoint, map[string]interface{}{"id": 1, "name": "Inbox", "inbox_project": true, "order": 1})
+	stub.add(ProjectsEndpoint, map[string]interface{}{"id": 2, "name": "Work", "color": 30, "order": 2})
+	stub.add(ProjectsEndpoint, map[string]interface{}{"id": 3, "name": "Old", "parent_id": 2, "order": 1})
+	stub.add(ProjectsEndpoint, map[string]interface{}{"id": 4, "name": "Hobby", "order": 3})
+	stub.add(SectionsEndpoint, map[string]interface{}{"id": 5, "project_id": 2, "name": "Now"})
+	stub.add(SectionsEndpoint, map[string]interface{}{"id": 6, "project_id": 2, "name": "Stale"})
+	stub.add(LabelsEndpoint, map[string]interface{}{"id": 7, "name": "urgent", "color": 30})
+	stub.add(LabelsEndpoint, map[string]interface{}{"id": 8, "name": "stale"})
+
+	return stub
+}
+
+func TestParseWorkspaceSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"spec", workspaceSpec, false},
+		{"empty", "", false},
+		{"json", `{"projects": [{"name": "Work", "sections": ["Now"]}]}`, false},
+		{"label without name", "labels: [{color: 30}]", true},
+		{"duplicate label", "labels: [{name: a}, {name: a}]", true},
+		{"project without name", "projects: [{color: 30}]", true},
+		{"project with separator", "projects: [{name: a/b}]", true},
+		{"duplicate project", "projects: [{name: a, projects: [{name: b}, {name: b}]}]", true},
+		{"duplicate section", "projects: [{name: a, sections: [x, x]}]", true},
+		{"invalid yaml", "projects: [", true},
+	}
+
+	for _, test := range tests {
+		if _, err := ParseWorkspaceSpec(strings.NewReader(test.input)); (err != nil) != test.wantErr {
+			t.Errorf("%s: got error %v, want error %t", test.name, err, test.wantErr)
+		}
+	}
+}
+
+func TestPlanWorkspace(t *testing.T) {
+	tests := []struct {
+		name  string
+		prune bool
+		want  string
+	}{
+		{
+			name: "keep",
+			want: `~ project Work (color: 30 -> 31)
++ section Work/Later
++ project Work/Reports
++ section Work/Reports/Q1
++ label new
+
+Plan: 4 to create, 1 to update, 0 to delete.
+`,
+		},
+		{
+			name:  "prune",
+			prune: true,
+			want: `~ project Work (color: 30 -> 31)
++ section Work/Later
++ project Work/Reports
++ section Work/Reports/Q1
++ label new
+- section Work/Stale
+- project Work/Old
+- project Hobby
+- label stale
+
+Plan: 4 to create, 1 to update, 4 to delete.
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec, err := ParseWorkspaceSpec(strings.NewReader(workspaceSpec))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			plan, err := newWorkspaceStub(t).client().PlanWorkspace(context.Background(), spec, test.prune)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := plan.String(); got != test.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestApplyWorkspace(t *testing.T) {
+	stub := newWorkspaceStub(t)
+	client := stub.client()
+	ctx := context.Background()
+
+	spec, err := ParseWorkspaceSpec(strings.NewReader(workspaceSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := client.PlanWorkspace(ctx, spec, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = client.ApplyWorkspace(ctx, plan); err != nil {
+		t.Fatal(err)
+	}
+
+	names := func(collection string) map[string]map[string]interface{} {
+		byName := make(map[string]map[string]interface{})
+		for _, object := range stub.objects(collection) {
+			byName[stubString(object["name"])] = object
+		}
+
+		return byName
+	}
+
+	projects, sections, labels := names(ProjectsEndpoint), names(SectionsEndpoint), names(LabelsEndpoint)
+	if len(projects) != 3 || projects["Inbox"] == nil || projects["Work"] == nil || projects["Reports"] == nil {
+		t.Fatalf("got projects %v, want Inbox, Work and Reports", projects)
+	}
+	if len(sections) != 3 || len(labels) != 2 || labels["new"] == nil {
+		t.Fatalf("got sections %v and labels %v", sections, labels)
+	}
+
+	tests := []struct {
+		name string
+		got  interface{}
+		want string
+	}{
+		{"work color", projects["Work"]["color"], "31"},
+		{"reports parent", projects["Reports"]["parent_id"], "2"},
+		{"later project", sections["Later"]["project_id"], "2"},
+		{"later order", sections["Later"]["order"], "2"},
+		{"q1 project", sections["Q1"]["project_id"], stubString(projects["Reports"]["id"])},
+		{"new label favorite", labels["new"]["favorite"], "true"},
+		{"urgent color", labels["urgent"]["color"], "30"},
+	}
+
+	for _, test := range tests {
+		if got := stubString(test.got); got != test.want {
+			t.Errorf("%s: got %s, want %s", test.name, got, test.want)
+		}
+	}
+
+	if plan, err = client.PlanWorkspace(ctx, spec, true); err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Errorf("got a second plan:\n%s", plan)
+	}
+}